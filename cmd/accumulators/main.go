@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"pharmacyclaims/internal/core"
+	"pharmacyclaims/internal/database"
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/repository"
+	"pharmacyclaims/internal/service"
+)
+
+func main() {
+	memberID := flag.String("member", "", "Only recompute accumulators for this member ID")
+	planYear := flag.Int("plan-year", 0, "Only recompute accumulators for this plan year")
+	flag.Parse()
+
+	cfg := core.LoadConfig()
+
+	db, err := database.NewConnection(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	accumulatorService := service.NewAccumulatorService(repository.NewPostgresRepository(db), core.NewLogger(cfg.LogDir))
+
+	adjustments, err := accumulatorService.RecomputeAccumulators(models.AccumulatorFilter{
+		MemberID: *memberID,
+		PlanYear: *planYear,
+	})
+	if err != nil {
+		log.Fatalf("Failed to recompute accumulators: %v", err)
+	}
+
+	for _, adjustment := range adjustments {
+		log.Printf("Adjusted %s %d by deductible %.2f, out-of-pocket %.2f",
+			adjustment.MemberID, adjustment.PlanYear, adjustment.Deductible, adjustment.OOP)
+	}
+	log.Printf("Recomputed accumulators with %d adjustments", len(adjustments))
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"pharmacyclaims/internal/core"
+	"pharmacyclaims/internal/database"
+	"pharmacyclaims/internal/repository"
+	"pharmacyclaims/internal/service"
+)
+
+func main() {
+	filename := flag.String("file", "", "NPPES bulk file (npidata_pfile) or a CSV subset with the same column names")
+	flag.Parse()
+
+	if *filename == "" {
+		log.Fatal("Missing -file")
+	}
+
+	cfg := core.LoadConfig()
+
+	db, err := database.NewConnection(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	file, err := os.Open(*filename)
+	if err != nil {
+		log.Fatalf("Failed to open NPPES file: %v", err)
+	}
+	defer file.Close()
+
+	loaderService := service.NewLoaderService(repository.NewPostgresRepository(db), core.NewLogger(cfg.LogDir))
+
+	imported, err := loaderService.ImportNPPES(file)
+	if err != nil {
+		log.Fatalf("Failed to import NPPES file after %d records: %v", imported, err)
+	}
+	log.Printf("Imported %d NPI records", imported)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+	"os"
+
+	"pharmacyclaims/internal/core"
+	"pharmacyclaims/internal/database"
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/ndc"
+	"pharmacyclaims/internal/repository"
+	"pharmacyclaims/internal/service"
+)
+
+func main() {
+	report := flag.String("report", "metrics", "Report to export (metrics, quantities)")
+	npi := flag.String("npi", "", "Only include claims for this NPI")
+	product := flag.String("ndc", "", "Only include claims for this NDC")
+	limit := flag.Int("limit", 0, "Number of quantities per NDC for the quantities report")
+	output := flag.String("output", "-", "Output file path (- for stdout)")
+	flag.Parse()
+
+	productNDC := ndc.Normalize(*product)
+
+	cfg := core.LoadConfig()
+
+	db, err := database.NewConnection(cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	reportsService := service.NewReportsService(repository.NewPostgresRepository(db))
+
+	var out io.Writer = os.Stdout
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("Failed to create output file: %v", err)
+		}
+		defer file.Close()
+		out = file
+	}
+
+	switch *report {
+	case "metrics":
+		filter := models.MetricsFilter{NPI: *npi, NDC: productNDC}
+		if err := reportsService.ExportClaimMetrics(filter, out); err != nil {
+			log.Fatalf("Failed to export claim metrics: %v", err)
+		}
+	case "quantities":
+		filter := models.QuantityFilter{Limit: *limit}
+		if productNDC != "" {
+			filter.NDCs = []string{productNDC}
+		}
+		if err := reportsService.ExportMostPrescribedQuantities(filter, out); err != nil {
+			log.Fatalf("Failed to export prescribed quantities: %v", err)
+		}
+	default:
+		log.Fatalf("Unknown report %q", *report)
+	}
+}
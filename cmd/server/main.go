@@ -39,12 +39,61 @@ func main() {
 	fileLogger := core.NewLogger(cfg.LogDir)
 
 	loaderService := service.NewLoaderService(repo, fileLogger)
-	claimsService := service.NewClaimsService(repo, fileLogger)
+	claimsService := service.NewClaimsServiceWithPolicy(repo, fileLogger, service.ClaimsPolicy{
+		IdempotencyRetention: cfg.IdempotencyRetention,
+		DuplicateWindow:      cfg.DuplicateWindow,
+		DuplicateAction:      cfg.DuplicateAction,
+		ReversalWindow:       cfg.ReversalWindow,
+		ReversalOverrideRole: cfg.ReversalOverrideRole,
+		MaxClaimQuantity:     cfg.MaxClaimQuantity,
+		MaxClaimAmount:       cfg.MaxClaimAmount,
+		PricingFormula: service.PricingFormula{
+			Basis:         cfg.PricingBasis,
+			Percent:       cfg.PricingPercent,
+			DispensingFee: cfg.DispensingFee,
+		},
+		DURLookback:          cfg.DURLookback,
+		StepTherapyLookback:  cfg.StepTherapyLookback,
+		RefillTooSoonPercent: cfg.RefillTooSoonPercent,
+	})
+	reportsService := service.NewReportsService(repo)
+	pharmacyService := service.NewPharmacyService(repo, fileLogger)
+	contractService := service.NewContractService(repo, fileLogger)
+	accumulatorService := service.NewAccumulatorService(repo, fileLogger)
+	priorAuthService := service.NewPriorAuthService(repo, fileLogger)
+
+	if err := loaderService.LoadNPPESFromData(cfg.DataDir); err != nil {
+		log.Printf("Warning: Failed to load NPPES data: %v", err)
+	}
 
 	if err := loaderService.LoadPharmaciesFromData(cfg.DataDir); err != nil {
 		log.Printf("Warning: Failed to load pharmacy data: %v", err)
 	}
 
+	if err := loaderService.LoadMembersFromData(cfg.DataDir); err != nil {
+		log.Printf("Warning: Failed to load member data: %v", err)
+	}
+
+	if err := loaderService.LoadFormularyFromData(cfg.DataDir); err != nil {
+		log.Printf("Warning: Failed to load formulary data: %v", err)
+	}
+
+	if err := loaderService.LoadBenefitPlansFromData(cfg.DataDir); err != nil {
+		log.Printf("Warning: Failed to load benefit plan data: %v", err)
+	}
+
+	if err := loaderService.LoadDrugClassesFromData(cfg.DataDir); err != nil {
+		log.Printf("Warning: Failed to load drug class data: %v", err)
+	}
+
+	if err := loaderService.LoadDrugInteractionsFromData(cfg.DataDir); err != nil {
+		log.Printf("Warning: Failed to load drug interaction data: %v", err)
+	}
+
+	if err := loaderService.LoadDrugPricesFromData(cfg.DataDir); err != nil {
+		log.Printf("Warning: Failed to load drug price data: %v", err)
+	}
+
 	if err := loaderService.LoadClaimsFromData(cfg.DataDir); err != nil {
 		log.Printf("Warning: Failed to load claims data: %v", err)
 	}
@@ -53,9 +102,17 @@ func main() {
 		log.Printf("Warning: Failed to load reversals data: %v", err)
 	}
 
-	handler := handlers.NewHttpHandler(claimsService)
+	go purgeExpiredIdempotencyKeys(claimsService, time.Hour)
+
+	handler := handlers.NewHttpHandlerWithRoleTokens(claimsService, cfg.RoleTokens)
 
 	router := handler.SetupRoutes()
+	handlers.NewReportsHandler(reportsService).RegisterRoutes(router)
+	handlers.NewPharmacyHandler(pharmacyService).RegisterRoutes(router)
+	handlers.NewAccumulatorHandler(accumulatorService).RegisterRoutes(router)
+	handlers.NewContractHandler(contractService).RegisterRoutes(router)
+	handlers.NewPriorAuthHandler(priorAuthService).RegisterRoutes(router)
+	handlers.NewNCPDPHandler(claimsService).RegisterRoutes(router)
 
 	server := &http.Server{
 		Addr:         ":" + strconv.Itoa(cfg.Port),
@@ -87,3 +144,19 @@ func main() {
 
 	log.Println("Server shutdown complete")
 }
+
+func purgeExpiredIdempotencyKeys(claimsService *service.ClaimsService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleted, err := claimsService.PurgeExpiredIdempotencyKeys()
+		if err != nil {
+			log.Printf("Warning: Failed to purge expired idempotency keys: %v", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("Purged %d expired idempotency keys", deleted)
+		}
+	}
+}
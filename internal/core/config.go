@@ -4,16 +4,32 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"pharmacyclaims/internal/database"
 )
 
 type Config struct {
-	Database      database.Connection
-	Port          int
-	DataDir       string
-	LogDir        string
-	MigrationsDir string
+	Database             database.Connection
+	Port                 int
+	DataDir              string
+	LogDir               string
+	MigrationsDir        string
+	IdempotencyRetention time.Duration
+	DuplicateWindow      time.Duration
+	DuplicateAction      string
+	ReversalWindow       time.Duration
+	ReversalOverrideRole string
+	RoleTokens           map[string]string
+	MaxClaimQuantity     float64
+	MaxClaimAmount       float64
+	PricingBasis         string
+	PricingPercent       float64
+	DispensingFee        float64
+	DURLookback          time.Duration
+	StepTherapyLookback  time.Duration
+	RefillTooSoonPercent float64
 }
 
 func LoadConfig() Config {
@@ -26,10 +42,24 @@ func LoadConfig() Config {
 			DBName:   getEnvWithDefault("DB_NAME", "pharmacy_claims"),
 			SSLMode:  getEnvWithDefault("DB_SSLMODE", "disable"),
 		},
-		Port:          getEnvIntWithDefault("PORT", 8080),
-		DataDir:       getEnvWithDefault("DATA_DIR", "./data"),
-		LogDir:        getEnvWithDefault("LOG_DIR", "./logs"),
-		MigrationsDir: getEnvWithDefault("MIGRATIONS_DIR", "./migrations"),
+		Port:                 getEnvIntWithDefault("PORT", 8080),
+		DataDir:              getEnvWithDefault("DATA_DIR", "./data"),
+		LogDir:               getEnvWithDefault("LOG_DIR", "./logs"),
+		MigrationsDir:        getEnvWithDefault("MIGRATIONS_DIR", "./migrations"),
+		IdempotencyRetention: time.Duration(getEnvIntWithDefault("IDEMPOTENCY_RETENTION_HOURS", 24)) * time.Hour,
+		DuplicateWindow:      time.Duration(getEnvIntWithDefault("DUPLICATE_CLAIM_WINDOW_MINUTES", 1440)) * time.Minute,
+		DuplicateAction:      getEnvWithDefault("DUPLICATE_CLAIM_ACTION", "reject"),
+		ReversalWindow:       time.Duration(getEnvIntWithDefault("REVERSAL_WINDOW_DAYS", 90)) * 24 * time.Hour,
+		ReversalOverrideRole: getEnvWithDefault("REVERSAL_OVERRIDE_ROLE", "supervisor"),
+		RoleTokens:           getEnvRoleTokens("ROLE_TOKENS"),
+		MaxClaimQuantity:     float64(getEnvIntWithDefault("CLAIM_MAX_QUANTITY", 10000)),
+		MaxClaimAmount:       float64(getEnvIntWithDefault("CLAIM_MAX_AMOUNT", 25000)),
+		PricingBasis:         getEnvWithDefault("PRICING_BASIS", "awp"),
+		PricingPercent:       getEnvFloatWithDefault("PRICING_PERCENT", -15),
+		DispensingFee:        getEnvFloatWithDefault("DISPENSING_FEE", 2.00),
+		DURLookback:          time.Duration(getEnvIntWithDefault("DUR_LOOKBACK_DAYS", 180)) * 24 * time.Hour,
+		StepTherapyLookback:  time.Duration(getEnvIntWithDefault("STEP_THERAPY_LOOKBACK_DAYS", 365)) * 24 * time.Hour,
+		RefillTooSoonPercent: getEnvFloatWithDefault("REFILL_TOO_SOON_PERCENT", 75),
 	}
 
 	return config
@@ -51,3 +81,29 @@ func getEnvIntWithDefault(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvFloatWithDefault(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+		log.Printf("Warning: Invalid number value for %s: %s, using default %g", key, value, defaultValue)
+	}
+	return defaultValue
+}
+
+func getEnvRoleTokens(key string) map[string]string {
+	tokens := map[string]string{}
+	for _, pair := range strings.Split(os.Getenv(key), ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		role, token, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || role == "" || token == "" {
+			log.Printf("Warning: Invalid role token in %s, expected role:token", key)
+			continue
+		}
+		tokens[role] = token
+	}
+	return tokens
+}
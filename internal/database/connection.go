@@ -55,7 +55,7 @@ func (db *DB) BeginTx() (*sql.Tx, error) {
 	return db.Begin()
 }
 
-func (db *DB) ExecuteInTransaction(fn func(*sql.Tx) error) error {
+func (db *DB) ExecuteInTransaction(fn func(*sql.Tx) error) (err error) {
 	tx, err := db.BeginTx()
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
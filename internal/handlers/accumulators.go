@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/utility"
+)
+
+type AccumulatorServiceInterface interface {
+	GetAccumulator(filter models.AccumulatorFilter) (*models.Accumulator, error)
+}
+
+type AccumulatorHandler struct {
+	service AccumulatorServiceInterface
+}
+
+func NewAccumulatorHandler(service AccumulatorServiceInterface) *AccumulatorHandler {
+	return &AccumulatorHandler{service: service}
+}
+
+func (h *AccumulatorHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/members/{member_id}/accumulators", h.GetAccumulator)
+}
+
+func (h *AccumulatorHandler) GetAccumulator(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only GET method is allowed")
+		return
+	}
+
+	filter := models.AccumulatorFilter{MemberID: r.PathValue("member_id")}
+
+	if value := r.URL.Query().Get("as_of"); value != "" {
+		asOf, err := time.Parse(utility.ServiceDateLayout, value)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid as_of", "as_of must be a date (YYYY-MM-DD)")
+			return
+		}
+		filter.AsOf = &asOf
+	}
+
+	if value := r.URL.Query().Get("plan_year"); value != "" {
+		planYear, err := strconv.Atoi(value)
+		if err != nil || planYear <= 0 {
+			sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid plan_year", "plan_year must be a positive integer")
+			return
+		}
+		filter.PlanYear = planYear
+	}
+
+	accumulator, err := h.service.GetAccumulator(filter)
+	if err != nil {
+		sendServiceError(w, err, "Failed to get accumulator")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, accumulator)
+}
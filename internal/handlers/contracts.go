@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/utility"
+)
+
+type ContractServiceInterface interface {
+	ValidateContract(request models.ContractRequest) error
+	ListContracts(filter models.ContractFilter) ([]models.Contract, error)
+	GetContract(id int) (*models.Contract, error)
+	CreateContract(request models.ContractRequest) (*models.Contract, error)
+	UpdateContract(id int, request models.ContractRequest) (*models.Contract, error)
+	GetContractHistory(id int) ([]models.ContractChange, error)
+}
+
+type ContractHandler struct {
+	service ContractServiceInterface
+}
+
+func NewContractHandler(service ContractServiceInterface) *ContractHandler {
+	return &ContractHandler{service: service}
+}
+
+func (h *ContractHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/contracts", h.handleCollection)
+	mux.HandleFunc("/contracts/{id}", h.handleItem)
+	mux.HandleFunc("/contracts/{id}/history", h.GetContractHistory)
+}
+
+func (h *ContractHandler) handleCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.ListContracts(w, r)
+	case http.MethodPost:
+		h.CreateContract(w, r)
+	default:
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only GET and POST methods are allowed")
+	}
+}
+
+func (h *ContractHandler) handleItem(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetContract(w, r)
+	case http.MethodPut:
+		h.UpdateContract(w, r)
+	default:
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only GET and PUT methods are allowed")
+	}
+}
+
+func (h *ContractHandler) ListContracts(w http.ResponseWriter, r *http.Request) {
+	filter := models.ContractFilter{
+		Chain: r.URL.Query().Get("chain"),
+	}
+
+	if value := r.URL.Query().Get("date"); value != "" {
+		date, err := time.Parse(utility.ServiceDateLayout, value)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid date", "date must be a date (YYYY-MM-DD)")
+			return
+		}
+		filter.Date = &date
+	}
+
+	contracts, err := h.service.ListContracts(filter)
+	if err != nil {
+		sendServiceError(w, err, "Failed to list contracts")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, contracts)
+}
+
+func (h *ContractHandler) GetContract(w http.ResponseWriter, r *http.Request) {
+	id, ok := contractID(w, r)
+	if !ok {
+		return
+	}
+
+	contract, err := h.service.GetContract(id)
+	if err != nil {
+		sendServiceError(w, err, "Failed to get contract")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, contract)
+}
+
+func (h *ContractHandler) CreateContract(w http.ResponseWriter, r *http.Request) {
+	var request models.ContractRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidJSON, "Invalid JSON format", err.Error())
+		return
+	}
+
+	if err := h.service.ValidateContract(request); err != nil {
+		sendValidationError(w, err)
+		return
+	}
+
+	contract, err := h.service.CreateContract(request)
+	if err != nil {
+		sendServiceError(w, err, "Failed to create contract")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusCreated, contract)
+}
+
+func (h *ContractHandler) UpdateContract(w http.ResponseWriter, r *http.Request) {
+	id, ok := contractID(w, r)
+	if !ok {
+		return
+	}
+
+	var request models.ContractRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidJSON, "Invalid JSON format", err.Error())
+		return
+	}
+
+	if err := h.service.ValidateContract(request); err != nil {
+		sendValidationError(w, err)
+		return
+	}
+
+	contract, err := h.service.UpdateContract(id, request)
+	if err != nil {
+		sendServiceError(w, err, "Failed to update contract")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, contract)
+}
+
+func (h *ContractHandler) GetContractHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only GET method is allowed")
+		return
+	}
+
+	id, ok := contractID(w, r)
+	if !ok {
+		return
+	}
+
+	changes, err := h.service.GetContractHistory(id)
+	if err != nil {
+		sendServiceError(w, err, "Failed to get contract history")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, changes)
+}
+
+func contractID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid contract ID", "id must be a positive integer")
+		return 0, false
+	}
+	return id, true
+}
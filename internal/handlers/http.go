@@ -1,11 +1,18 @@
 package handlers
 
 import (
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/ndc"
+	"pharmacyclaims/internal/utility"
 
 	"github.com/google/uuid"
 )
@@ -13,15 +20,26 @@ import (
 type ServiceInterface interface {
 	ValidateClaim(request models.ClaimRequest) error
 	SubmitClaim(request models.ClaimRequest) (*models.ClaimResponse, error)
+	ValidateReversal(request models.ReversalRequest) error
 	ReverseClaim(request models.ReversalRequest) (*models.ReversalResponse, error)
+	ValidateRebill(request models.RebillRequest) error
+	RebillClaim(request models.RebillRequest) (*models.RebillResponse, error)
+	GetClaim(id uuid.UUID) (*models.ClaimDetails, error)
+	ValidateClaimSearch(filter models.ClaimSearchFilter) error
+	SearchClaims(filter models.ClaimSearchFilter) (*models.ClaimSearchResponse, error)
 }
 
 type HttpHandler struct {
-	service ServiceInterface
+	service    ServiceInterface
+	roleTokens map[string]string
 }
 
 func NewHttpHandler(service ServiceInterface) *HttpHandler {
-	return &HttpHandler{service: service}
+	return NewHttpHandlerWithRoleTokens(service, nil)
+}
+
+func NewHttpHandlerWithRoleTokens(service ServiceInterface, roleTokens map[string]string) *HttpHandler {
+	return &HttpHandler{service: service, roleTokens: roleTokens}
 }
 
 func (h *HttpHandler) SetupRoutes() *http.ServeMux {
@@ -29,6 +47,9 @@ func (h *HttpHandler) SetupRoutes() *http.ServeMux {
 
 	mux.HandleFunc("/claim", h.SubmitClaim)
 	mux.HandleFunc("/reversal", h.ReverseClaim)
+	mux.HandleFunc("/claims", h.SearchClaims)
+	mux.HandleFunc("/claims/{id}", h.GetClaim)
+	mux.HandleFunc("/claims/{id}/rebill", h.RebillClaim)
 	mux.HandleFunc("/health", h.HealthCheck)
 
 	return mux
@@ -36,80 +57,253 @@ func (h *HttpHandler) SetupRoutes() *http.ServeMux {
 
 func (h *HttpHandler) SubmitClaim(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		h.sendErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed", "Only POST method is allowed")
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only POST method is allowed")
 		return
 	}
 
 	var request models.ClaimRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Invalid JSON format", err.Error())
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidJSON, "Invalid JSON format", err.Error())
 		return
 	}
 
+	request.NDC = ndc.Normalize(request.NDC)
+	request.IdempotencyKey = r.Header.Get("Idempotency-Key")
+
 	if err := h.service.ValidateClaim(request); err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Validation failed", err.Error())
+		sendValidationError(w, err)
 		return
 	}
 
 	response, err := h.service.SubmitClaim(request)
 	if err != nil {
-		if err.Error() == "pharmacy with NPI "+request.NPI+" not found" {
-			h.sendErrorResponse(w, http.StatusNotFound, "Pharmacy not found", err.Error())
-			return
-		}
-		h.sendErrorResponse(w, http.StatusInternalServerError, "Failed to submit claim", err.Error())
+		sendServiceError(w, err, "Failed to submit claim")
 		return
 	}
 
-	h.sendJSONResponse(w, http.StatusCreated, response)
+	sendJSONResponse(w, http.StatusCreated, response)
 }
 
 func (h *HttpHandler) ReverseClaim(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		h.sendErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed", "Only POST method is allowed")
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only POST method is allowed")
 		return
 	}
 
 	var request models.ReversalRequest
 
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Invalid JSON format", err.Error())
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidJSON, "Invalid JSON format", err.Error())
 		return
 	}
 
+	request.NDC = ndc.Normalize(request.NDC)
+	request.Role = h.requestRole(r)
+
 	if request.ClaimID == uuid.Nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Invalid claim_id", "claim_id must be a valid UUID")
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid claim_id", "claim_id must be a valid UUID")
+		return
+	}
+
+	if err := h.service.ValidateReversal(request); err != nil {
+		sendValidationError(w, err)
 		return
 	}
 
 	response, err := h.service.ReverseClaim(request)
 	if err != nil {
-		if err.Error() == "claim with ID "+request.ClaimID.String()+" not found" {
-			h.sendErrorResponse(w, http.StatusNotFound, "Claim not found", err.Error())
+		sendServiceError(w, err, "Failed to reverse claim")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, response)
+}
+
+func (h *HttpHandler) RebillClaim(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only POST method is allowed")
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid claim_id", "claim_id must be a valid UUID")
+		return
+	}
+
+	var request models.RebillRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidJSON, "Invalid JSON format", err.Error())
+		return
+	}
+
+	request.ClaimID = id
+	request.NDC = ndc.Normalize(request.NDC)
+	request.Role = h.requestRole(r)
+
+	if err := h.service.ValidateRebill(request); err != nil {
+		sendValidationError(w, err)
+		return
+	}
+
+	response, err := h.service.RebillClaim(request)
+	if err != nil {
+		sendServiceError(w, err, "Failed to rebill claim")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusCreated, response)
+}
+
+func (h *HttpHandler) GetClaim(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only GET method is allowed")
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid claim_id", "claim_id must be a valid UUID")
+		return
+	}
+
+	claim, err := h.service.GetClaim(id)
+	if err != nil {
+		sendServiceError(w, err, "Failed to get claim")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, claim)
+}
+
+func (h *HttpHandler) SearchClaims(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only GET method is allowed")
+		return
+	}
+
+	query := r.URL.Query()
+	filter := models.ClaimSearchFilter{
+		NPI:                query.Get("npi"),
+		NDC:                ndc.Normalize(query.Get("ndc")),
+		DAWCode:            query.Get("daw_code"),
+		PrescriptionNumber: query.Get("prescription_number"),
+		PrescriberNPI:      query.Get("prescriber_npi"),
+		Status:             query.Get("status"),
+		Cursor:             query.Get("cursor"),
+	}
+
+	for _, param := range []struct {
+		name   string
+		target **int
+	}{
+		{"days_supply", &filter.DaysSupply},
+		{"fill_number", &filter.FillNumber},
+	} {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid "+param.name, param.name+" must be an integer")
 			return
 		}
-		if err.Error() == "claim is already reversed" {
-			h.sendErrorResponse(w, http.StatusConflict, "Claim already reversed", err.Error())
+		*param.target = &parsed
+	}
+
+	if value := query.Get("date_written"); value != "" {
+		dateWritten, err := time.Parse(utility.ServiceDateLayout, value)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid date_written", "date_written must be a date (YYYY-MM-DD)")
+			return
+		}
+		filter.DateWritten = &dateWritten
+	}
+
+	var err error
+	if filter.From, err = parseTimeParam(query.Get("from"), false); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid from", "from must be a date (YYYY-MM-DD) or RFC3339 timestamp")
+		return
+	}
+	if filter.To, err = parseTimeParam(query.Get("to"), true); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid to", "to must be a date (YYYY-MM-DD) or RFC3339 timestamp")
+		return
+	}
+
+	if value := query.Get("reversed"); value != "" {
+		reversed, err := strconv.ParseBool(value)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid reversed", "reversed must be true or false")
 			return
 		}
-		h.sendErrorResponse(w, http.StatusInternalServerError, "Failed to reverse claim", err.Error())
+		filter.Reversed = &reversed
+	}
+
+	if filter.Limit, err = queryInt(r, "limit"); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid limit", "limit must be an integer")
+		return
+	}
+
+	if err := h.service.ValidateClaimSearch(filter); err != nil {
+		sendValidationError(w, err)
+		return
+	}
+
+	response, err := h.service.SearchClaims(filter)
+	if err != nil {
+		sendServiceError(w, err, "Failed to search claims")
 		return
 	}
 
-	h.sendJSONResponse(w, http.StatusOK, response)
+	sendJSONResponse(w, http.StatusOK, response)
 }
 
 func (h *HttpHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
-		h.sendErrorResponse(w, http.StatusMethodNotAllowed, "Method not allowed", "Only GET method is allowed")
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only GET method is allowed")
 		return
 	}
 
-	h.sendJSONResponse(w, http.StatusOK, map[string]string{"status": "healthy"})
+	sendJSONResponse(w, http.StatusOK, map[string]string{"status": "healthy"})
 }
 
-func (h *HttpHandler) sendJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+func parseTimeParam(value string, endOfDay bool) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1)
+	}
+	return &t, nil
+}
+
+func (h *HttpHandler) requestRole(r *http.Request) string {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return ""
+	}
+
+	for role, roleToken := range h.roleTokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(roleToken)) == 1 {
+			return role
+		}
+	}
+	return ""
+}
+
+func sendJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 
@@ -118,11 +312,76 @@ func (h *HttpHandler) sendJSONResponse(w http.ResponseWriter, statusCode int, da
 	}
 }
 
-func (h *HttpHandler) sendErrorResponse(w http.ResponseWriter, statusCode int, error, message string) {
+func sendErrorResponse(w http.ResponseWriter, statusCode int, code, error, message string) {
 	response := models.ErrorResponse{
 		Error:   error,
+		Code:    code,
 		Message: message,
 	}
 
-	h.sendJSONResponse(w, statusCode, response)
+	sendJSONResponse(w, statusCode, response)
+}
+
+func sendValidationError(w http.ResponseWriter, err error) {
+	response := models.ErrorResponse{
+		Error:   "Validation failed",
+		Code:    models.CodeValidationFailed,
+		Message: err.Error(),
+	}
+
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		response.Field = validationErr.Field
+	}
+
+	sendJSONResponse(w, http.StatusBadRequest, response)
+}
+
+var domainErrors = []struct {
+	target     error
+	statusCode int
+	code       string
+	error      string
+}{
+	{models.ErrPharmacyNotFound, http.StatusNotFound, models.CodePharmacyNotFound, "Pharmacy not found"},
+	{models.ErrPharmacyExists, http.StatusConflict, models.CodePharmacyExists, "Pharmacy already exists"},
+	{models.ErrClaimNotFound, http.StatusNotFound, models.CodeClaimNotFound, "Claim not found"},
+	{models.ErrAlreadyReversed, http.StatusConflict, models.CodeAlreadyReversed, "Claim already reversed"},
+	{models.ErrClaimRejected, http.StatusUnprocessableEntity, models.CodeClaimRejected, "Claim rejected"},
+	{models.ErrReversalMismatch, http.StatusForbidden, models.CodeReversalMismatch, "Reversal does not match claim"},
+	{models.ErrReversalWindow, http.StatusUnprocessableEntity, models.CodeReversalWindow, "Reversal window expired"},
+	{models.ErrContractNotFound, http.StatusNotFound, models.CodeContractNotFound, "Contract not found"},
+	{models.ErrContractOverlap, http.StatusConflict, models.CodeContractOverlap, "Contract overlap"},
+	{models.ErrMemberNotFound, http.StatusNotFound, models.CodeMemberNotFound, "Member not found"},
+	{models.ErrPriorAuthNotFound, http.StatusNotFound, models.CodePriorAuthNotFound, "Prior authorization not found"},
+	{models.ErrPriorAuthUsed, http.StatusConflict, models.CodePriorAuthUsed, "Prior authorization quantity already used"},
+	{models.ErrNPINotRegistered, http.StatusUnprocessableEntity, models.CodeNPINotRegistered, "NPI not registered"},
+	{models.ErrNPINotPharmacy, http.StatusUnprocessableEntity, models.CodeNPINotPharmacy, "NPI is not a pharmacy"},
+	{models.ErrIdempotencyConflict, http.StatusUnprocessableEntity, models.CodeIdempotencyConflict, "Idempotency key conflict"},
+}
+
+func sendServiceError(w http.ResponseWriter, err error, fallback string) {
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		sendValidationError(w, err)
+		return
+	}
+
+	for _, domainErr := range domainErrors {
+		if errors.Is(err, domainErr.target) {
+			response := models.ErrorResponse{
+				Error:   domainErr.error,
+				Code:    domainErr.code,
+				Message: err.Error(),
+			}
+			var rejectedErr *models.RejectedError
+			if errors.As(err, &rejectedErr) {
+				response.Rejects = rejectedErr.Rejects
+			}
+			sendJSONResponse(w, domainErr.statusCode, response)
+			return
+		}
+	}
+
+	sendErrorResponse(w, http.StatusInternalServerError, models.CodeInternalError, fallback, err.Error())
 }
@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/ncpdp"
+)
+
+const maxNCPDPRequestSize = 64 * 1024
+
+type NCPDPServiceInterface interface {
+	SubmitClaim(request models.ClaimRequest) (*models.ClaimResponse, error)
+	ReverseClaim(request models.ReversalRequest) (*models.ReversalResponse, error)
+	FindClaimForReversal(match models.ReversalMatch) (*models.Claim, error)
+}
+
+type NCPDPHandler struct {
+	service NCPDPServiceInterface
+}
+
+func NewNCPDPHandler(service NCPDPServiceInterface) *NCPDPHandler {
+	return &NCPDPHandler{service: service}
+}
+
+func (h *NCPDPHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/ncpdp", h.ProcessTransmission)
+}
+
+func (h *NCPDPHandler) ProcessTransmission(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only POST method is allowed")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxNCPDPRequestSize))
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid NCPDP request", err.Error())
+		return
+	}
+
+	request, err := ncpdp.ParseRequest(body)
+	if request == nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid NCPDP request", err.Error())
+		return
+	}
+
+	response := ncpdp.NewResponse(request.Header)
+
+	if err != nil {
+		rejectCode := ncpdp.RejectClaimNotProcessed
+		var parseErr *ncpdp.ParseError
+		if errors.As(err, &parseErr) {
+			rejectCode = parseErr.RejectCode
+		}
+
+		response.Header.Status = ncpdp.HeaderStatusRejected
+		response.Message = err.Error()
+		response.Transactions = []ncpdp.TransactionResponse{{
+			Status:      ncpdp.TransactionStatusRejected,
+			RejectCodes: []string{rejectCode},
+		}}
+		sendNCPDPResponse(w, response)
+		return
+	}
+
+	for _, transaction := range request.Transactions {
+		var result ncpdp.TransactionResponse
+		if request.Header.TransactionCode == ncpdp.TransactionReversal {
+			result = h.reverse(request, transaction)
+		} else {
+			result = h.bill(request, transaction)
+		}
+
+		result.PrescriptionRefQualifier = transaction.Claim.PrescriptionRefQualifier
+		result.PrescriptionNumber = transaction.Claim.PrescriptionNumber
+		response.Transactions = append(response.Transactions, result)
+	}
+
+	sendNCPDPResponse(w, response)
+}
+
+func (h *NCPDPHandler) bill(request *ncpdp.Request, transaction ncpdp.Transaction) ncpdp.TransactionResponse {
+	claimRequest := transaction.ClaimRequest(request.Header, request.Insurance)
+
+	claimResponse, err := h.service.SubmitClaim(claimRequest)
+	if err != nil {
+		return rejectTransaction(err, ncpdp.RejectClaimNotProcessed)
+	}
+
+	if claimResponse.Status == models.ClaimStatusRejected {
+		messages := make([]string, len(claimResponse.Rejects))
+		result := ncpdp.TransactionResponse{
+			Status: ncpdp.TransactionStatusRejected,
+			DUR:    durResponses(claimResponse.DUR),
+		}
+		for i, reject := range claimResponse.Rejects {
+			result.RejectCodes = append(result.RejectCodes, reject.Code)
+			messages[i] = reject.Message
+		}
+		result.Message = fmt.Sprintf("claim %s rejected: %s", claimResponse.ClaimID, strings.Join(messages, "; "))
+		return result
+	}
+
+	message := fmt.Sprintf("claim %s", claimResponse.ClaimID)
+	if claimResponse.DuplicateOf != nil {
+		message += fmt.Sprintf(" duplicates claim %s", claimResponse.DuplicateOf)
+	}
+
+	result := ncpdp.TransactionResponse{
+		Status:          ncpdp.TransactionStatusPaid,
+		Message:         message,
+		TotalAmountPaid: &claimRequest.Price,
+		DUR:             durResponses(claimResponse.DUR),
+	}
+	if pricing := claimResponse.Pricing; pricing != nil {
+		result.IngredientCostPaid = &pricing.IngredientCost
+		result.DispensingFeePaid = &pricing.DispensingFee
+		result.TotalAmountPaid = &pricing.Total
+	}
+	if costShare := claimResponse.CostShare; costShare != nil {
+		result.PatientPayAmount = &costShare.PatientPay
+		result.AmountAppliedToDeductible = &costShare.Deductible
+		result.AmountOfCopay = &costShare.Copay
+		result.AmountOfCoinsurance = &costShare.Coinsurance
+		result.TotalAmountPaid = &costShare.PlanPaid
+	}
+
+	return result
+}
+
+func durResponses(alerts []models.DURAlert) []ncpdp.DURResponse {
+	var responses []ncpdp.DURResponse
+	for _, alert := range alerts {
+		otherPharmacy := ncpdp.OtherPharmacySame
+		if alert.OtherPharmacy {
+			otherPharmacy = ncpdp.OtherPharmacyOther
+		}
+		responses = append(responses, ncpdp.DURResponse{
+			ReasonCode:             alert.ReasonCode,
+			ClinicalSignificance:   alert.ClinicalSignificance,
+			OtherPharmacyIndicator: otherPharmacy,
+			PreviousFillDate:       alert.PreviousFillDate,
+			PreviousQuantity:       alert.PreviousQuantity,
+			DatabaseIndicator:      ncpdp.DURDatabaseProcessor,
+			Message:                alert.Message,
+		})
+	}
+	return responses
+}
+
+func (h *NCPDPHandler) reverse(request *ncpdp.Request, transaction ncpdp.Transaction) ncpdp.TransactionResponse {
+	claim, err := h.service.FindClaimForReversal(transaction.ReversalMatch(request.Header, request.Insurance))
+	if err != nil {
+		return rejectTransaction(err, ncpdp.RejectReversalNotProcessed)
+	}
+
+	reversalResponse, err := h.service.ReverseClaim(transaction.ReversalRequest(request.Header, claim.ID))
+	if err != nil {
+		return rejectTransaction(err, ncpdp.RejectReversalNotProcessed)
+	}
+
+	return ncpdp.TransactionResponse{
+		Status:  ncpdp.TransactionStatusApproved,
+		Message: fmt.Sprintf("claim %s reversed by %s", reversalResponse.ClaimID, reversalResponse.ReversalID),
+	}
+}
+
+var ncpdpRejects = []struct {
+	target error
+	code   string
+}{
+	{models.ErrPharmacyNotFound, ncpdp.RejectPharmacyNumber},
+	{models.ErrReversalWindow, ncpdp.RejectClaimTooOld},
+	{models.ErrClaimNotFound, ncpdp.RejectReversalNotProcessed},
+	{models.ErrClaimAmbiguous, ncpdp.RejectReversalNotProcessed},
+	{models.ErrAlreadyReversed, ncpdp.RejectReversalNotProcessed},
+	{models.ErrReversalMismatch, ncpdp.RejectReversalNotProcessed},
+	{models.ErrClaimRejected, ncpdp.RejectReversalNotProcessed},
+}
+
+var ncpdpFieldRejects = map[string]string{
+	"ndc":                 ncpdp.RejectProductID,
+	"npi":                 ncpdp.RejectPharmacyNumber,
+	"member_id":           ncpdp.RejectCardholderID,
+	"quantity":            ncpdp.RejectQuantityDispensed,
+	"price":               ncpdp.RejectGrossAmountDue,
+	"service_date":        ncpdp.RejectDateOfService,
+	"days_supply":         ncpdp.RejectDaysSupply,
+	"daw_code":            ncpdp.RejectDispenseAsWritten,
+	"fill_number":         ncpdp.RejectFillNumber,
+	"prescription_number": ncpdp.RejectPrescriptionNumber,
+	"date_written":        ncpdp.RejectDatePrescriptionWritten,
+	"prescriber_npi":      ncpdp.RejectPrescriberID,
+}
+
+func rejectTransaction(err error, fallback string) ncpdp.TransactionResponse {
+	return ncpdp.TransactionResponse{
+		Status:      ncpdp.TransactionStatusRejected,
+		RejectCodes: []string{ncpdpRejectCode(err, fallback)},
+		Message:     err.Error(),
+	}
+}
+
+func ncpdpRejectCode(err error, fallback string) string {
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		if code, ok := ncpdpFieldRejects[validationErr.Field]; ok {
+			return code
+		}
+		return fallback
+	}
+
+	for _, reject := range ncpdpRejects {
+		if errors.Is(err, reject.target) {
+			return reject.code
+		}
+	}
+
+	log.Printf("NCPDP transaction failed: %v", err)
+	return ncpdp.RejectHostProcessingError
+}
+
+func sendNCPDPResponse(w http.ResponseWriter, response *ncpdp.Response) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(ncpdp.EncodeResponse(response)); err != nil {
+		log.Printf("Failed to write NCPDP response: %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"pharmacyclaims/internal/models"
+)
+
+type PharmacyServiceInterface interface {
+	ValidatePharmacy(request models.PharmacyRequest) error
+	ListPharmacies(filter models.PharmacyFilter) ([]models.Pharmacy, error)
+	GetPharmacy(npi string) (*models.Pharmacy, error)
+	CreatePharmacy(request models.PharmacyRequest) (*models.Pharmacy, error)
+	UpdatePharmacy(npi string, request models.PharmacyRequest) (*models.Pharmacy, error)
+	DeactivatePharmacy(npi string) (*models.Pharmacy, error)
+}
+
+type PharmacyHandler struct {
+	service PharmacyServiceInterface
+}
+
+func NewPharmacyHandler(service PharmacyServiceInterface) *PharmacyHandler {
+	return &PharmacyHandler{service: service}
+}
+
+func (h *PharmacyHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/pharmacies", h.handleCollection)
+	mux.HandleFunc("/pharmacies/{npi}", h.handleItem)
+}
+
+func (h *PharmacyHandler) handleCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.ListPharmacies(w, r)
+	case http.MethodPost:
+		h.CreatePharmacy(w, r)
+	default:
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only GET and POST methods are allowed")
+	}
+}
+
+func (h *PharmacyHandler) handleItem(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		h.GetPharmacy(w, r)
+	case http.MethodPut:
+		h.UpdatePharmacy(w, r)
+	case http.MethodDelete:
+		h.DeactivatePharmacy(w, r)
+	default:
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only GET, PUT and DELETE methods are allowed")
+	}
+}
+
+func (h *PharmacyHandler) ListPharmacies(w http.ResponseWriter, r *http.Request) {
+	filter := models.PharmacyFilter{
+		Chain: r.URL.Query().Get("chain"),
+	}
+
+	if value := r.URL.Query().Get("active"); value != "" {
+		active, err := strconv.ParseBool(value)
+		if err != nil {
+			sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid active", "active must be true or false")
+			return
+		}
+		filter.Active = &active
+	}
+
+	pharmacies, err := h.service.ListPharmacies(filter)
+	if err != nil {
+		sendServiceError(w, err, "Failed to list pharmacies")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, pharmacies)
+}
+
+func (h *PharmacyHandler) GetPharmacy(w http.ResponseWriter, r *http.Request) {
+	npi := r.PathValue("npi")
+
+	pharmacy, err := h.service.GetPharmacy(npi)
+	if err != nil {
+		sendServiceError(w, err, "Failed to get pharmacy")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, pharmacy)
+}
+
+func (h *PharmacyHandler) CreatePharmacy(w http.ResponseWriter, r *http.Request) {
+	var request models.PharmacyRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidJSON, "Invalid JSON format", err.Error())
+		return
+	}
+
+	if err := h.service.ValidatePharmacy(request); err != nil {
+		sendValidationError(w, err)
+		return
+	}
+
+	pharmacy, err := h.service.CreatePharmacy(request)
+	if err != nil {
+		sendServiceError(w, err, "Failed to create pharmacy")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusCreated, pharmacy)
+}
+
+func (h *PharmacyHandler) UpdatePharmacy(w http.ResponseWriter, r *http.Request) {
+	npi := r.PathValue("npi")
+
+	var request models.PharmacyRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidJSON, "Invalid JSON format", err.Error())
+		return
+	}
+
+	if request.NPI != "" && request.NPI != npi {
+		sendValidationError(w, models.NewValidationError("npi", "npi in body does not match npi in path"))
+		return
+	}
+	request.NPI = npi
+
+	if err := h.service.ValidatePharmacy(request); err != nil {
+		sendValidationError(w, err)
+		return
+	}
+
+	pharmacy, err := h.service.UpdatePharmacy(npi, request)
+	if err != nil {
+		sendServiceError(w, err, "Failed to update pharmacy")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, pharmacy)
+}
+
+func (h *PharmacyHandler) DeactivatePharmacy(w http.ResponseWriter, r *http.Request) {
+	npi := r.PathValue("npi")
+
+	pharmacy, err := h.service.DeactivatePharmacy(npi)
+	if err != nil {
+		sendServiceError(w, err, "Failed to deactivate pharmacy")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, pharmacy)
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/ndc"
+)
+
+type PriorAuthServiceInterface interface {
+	ValidatePriorAuth(request models.PriorAuthRequest) error
+	GetPriorAuth(id int) (*models.PriorAuth, error)
+	CreatePriorAuth(request models.PriorAuthRequest) (*models.PriorAuth, error)
+}
+
+type PriorAuthHandler struct {
+	service PriorAuthServiceInterface
+}
+
+func NewPriorAuthHandler(service PriorAuthServiceInterface) *PriorAuthHandler {
+	return &PriorAuthHandler{service: service}
+}
+
+func (h *PriorAuthHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/prior-auths", h.CreatePriorAuth)
+	mux.HandleFunc("/prior-auths/{id}", h.GetPriorAuth)
+}
+
+func (h *PriorAuthHandler) GetPriorAuth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only GET method is allowed")
+		return
+	}
+
+	id, ok := priorAuthID(w, r)
+	if !ok {
+		return
+	}
+
+	priorAuth, err := h.service.GetPriorAuth(id)
+	if err != nil {
+		sendServiceError(w, err, "Failed to get prior authorization")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, priorAuth)
+}
+
+func (h *PriorAuthHandler) CreatePriorAuth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only POST method is allowed")
+		return
+	}
+
+	var request models.PriorAuthRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidJSON, "Invalid JSON format", err.Error())
+		return
+	}
+
+	request.NDC = ndc.Normalize(request.NDC)
+
+	if err := h.service.ValidatePriorAuth(request); err != nil {
+		sendValidationError(w, err)
+		return
+	}
+
+	priorAuth, err := h.service.CreatePriorAuth(request)
+	if err != nil {
+		sendServiceError(w, err, "Failed to create prior authorization")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusCreated, priorAuth)
+}
+
+func priorAuthID(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid prior authorization ID", "id must be a positive integer")
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/ndc"
+)
+
+type ReportsServiceInterface interface {
+	ValidateMetricsFilter(filter models.MetricsFilter) error
+	GetClaimMetrics(filter models.MetricsFilter) ([]models.ClaimMetrics, error)
+	ValidateRecommendationFilter(filter models.RecommendationFilter) error
+	GetChainRecommendations(filter models.RecommendationFilter) ([]models.ChainRecommendation, error)
+	ValidateQuantityFilter(filter models.QuantityFilter) error
+	GetMostPrescribedQuantities(filter models.QuantityFilter) ([]models.QuantityReport, error)
+}
+
+type ReportsHandler struct {
+	service ReportsServiceInterface
+}
+
+func NewReportsHandler(service ReportsServiceInterface) *ReportsHandler {
+	return &ReportsHandler{service: service}
+}
+
+func (h *ReportsHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/reports/metrics", h.GetClaimMetrics)
+	mux.HandleFunc("/reports/quantities", h.GetMostPrescribedQuantities)
+	mux.HandleFunc("/recommendations/chains", h.GetChainRecommendations)
+}
+
+func (h *ReportsHandler) GetClaimMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only GET method is allowed")
+		return
+	}
+
+	filter := models.MetricsFilter{
+		NPI: r.URL.Query().Get("npi"),
+		NDC: ndc.Normalize(r.URL.Query().Get("ndc")),
+	}
+
+	if err := h.service.ValidateMetricsFilter(filter); err != nil {
+		sendValidationError(w, err)
+		return
+	}
+
+	metrics, err := h.service.GetClaimMetrics(filter)
+	if err != nil {
+		sendServiceError(w, err, "Failed to get claim metrics")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, metrics)
+}
+
+func (h *ReportsHandler) GetChainRecommendations(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only GET method is allowed")
+		return
+	}
+
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid limit", "limit must be an integer")
+		return
+	}
+
+	filter := models.RecommendationFilter{
+		NDCs:  queryNDCs(r),
+		Limit: limit,
+	}
+
+	if err := h.service.ValidateRecommendationFilter(filter); err != nil {
+		sendValidationError(w, err)
+		return
+	}
+
+	recommendations, err := h.service.GetChainRecommendations(filter)
+	if err != nil {
+		sendServiceError(w, err, "Failed to get chain recommendations")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, recommendations)
+}
+
+func (h *ReportsHandler) GetMostPrescribedQuantities(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		sendErrorResponse(w, http.StatusMethodNotAllowed, models.CodeMethodNotAllowed, "Method not allowed", "Only GET method is allowed")
+		return
+	}
+
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		sendErrorResponse(w, http.StatusBadRequest, models.CodeInvalidParameter, "Invalid limit", "limit must be an integer")
+		return
+	}
+
+	filter := models.QuantityFilter{
+		NDCs:  queryNDCs(r),
+		Limit: limit,
+	}
+
+	if err := h.service.ValidateQuantityFilter(filter); err != nil {
+		sendValidationError(w, err)
+		return
+	}
+
+	reports, err := h.service.GetMostPrescribedQuantities(filter)
+	if err != nil {
+		sendServiceError(w, err, "Failed to get prescribed quantities")
+		return
+	}
+
+	sendJSONResponse(w, http.StatusOK, reports)
+}
+
+func queryList(r *http.Request, key string) []string {
+	var values []string
+	for _, raw := range r.URL.Query()[key] {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+func queryNDCs(r *http.Request) []string {
+	values := queryList(r, "ndc")
+	for i, value := range values {
+		values[i] = ndc.Normalize(value)
+	}
+	return values
+}
+
+func queryInt(r *http.Request, key string) (int, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
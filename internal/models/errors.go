@@ -0,0 +1,87 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrPharmacyNotFound  = errors.New("pharmacy not found")
+	ErrPharmacyExists    = errors.New("pharmacy already exists")
+	ErrClaimNotFound     = errors.New("claim not found")
+	ErrClaimAmbiguous    = errors.New("more than one claim matches")
+	ErrAlreadyReversed   = errors.New("claim already reversed")
+	ErrClaimRejected     = errors.New("claim rejected")
+	ErrReversalMismatch  = errors.New("reversal does not match original claim")
+	ErrReversalWindow    = errors.New("claim is outside the reversal window")
+	ErrDrugPriceNotFound = errors.New("drug price not found")
+	ErrContractNotFound  = errors.New("contract not found")
+	ErrMemberNotFound    = errors.New("member not found")
+	ErrNotOnFormulary    = errors.New("drug not on formulary")
+	ErrPlanNotFound      = errors.New("benefit plan not found")
+	ErrContractOverlap   = errors.New("contract overlaps an existing contract for the chain")
+	ErrPriorAuthNotFound = errors.New("prior authorization not found")
+	ErrPriorAuthUsed     = errors.New("prior authorization quantity already used")
+	ErrNPINotRegistered  = errors.New("npi not found in the NPPES registry")
+	ErrNPINotPharmacy    = errors.New("npi is not registered as a pharmacy")
+
+	ErrIdempotencyConflict    = errors.New("idempotency key reused with a different request")
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
+	ErrIdempotencyKeyInUse    = errors.New("idempotency key already in use")
+)
+
+const (
+	CodeMethodNotAllowed    = "method_not_allowed"
+	CodeInvalidJSON         = "invalid_json"
+	CodeInvalidParameter    = "invalid_parameter"
+	CodeValidationFailed    = "validation_failed"
+	CodePharmacyNotFound    = "pharmacy_not_found"
+	CodePharmacyExists      = "pharmacy_exists"
+	CodeClaimNotFound       = "claim_not_found"
+	CodeAlreadyReversed     = "claim_already_reversed"
+	CodeClaimRejected       = "claim_rejected"
+	CodeReversalMismatch    = "reversal_mismatch"
+	CodeReversalWindow      = "reversal_window_expired"
+	CodeContractNotFound    = "contract_not_found"
+	CodeContractOverlap     = "contract_overlap"
+	CodeMemberNotFound      = "member_not_found"
+	CodePriorAuthNotFound   = "prior_auth_not_found"
+	CodePriorAuthUsed       = "prior_auth_used"
+	CodeNPINotRegistered    = "npi_not_registered"
+	CodeNPINotPharmacy      = "npi_not_pharmacy"
+	CodeIdempotencyConflict = "idempotency_conflict"
+	CodeInternalError       = "internal_error"
+)
+
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func NewValidationError(field, message string) *ValidationError {
+	return &ValidationError{Field: field, Message: message}
+}
+
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+type RejectedError struct {
+	Rejects []Reject
+}
+
+func NewRejectedError(rejects []Reject) *RejectedError {
+	return &RejectedError{Rejects: rejects}
+}
+
+func (e *RejectedError) Error() string {
+	messages := make([]string, len(e.Rejects))
+	for i, reject := range e.Rejects {
+		messages[i] = reject.Code + " " + reject.Message
+	}
+	return ErrClaimRejected.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *RejectedError) Unwrap() error {
+	return ErrClaimRejected
+}
@@ -31,49 +31,476 @@ func (ct *CustomTime) UnmarshalJSON(data []byte) error {
 }
 
 type Pharmacy struct {
-	ID    int    `json:"id" db:"id"`
-	NPI   string `json:"npi" db:"npi"`
-	Chain string `json:"chain" db:"chain"`
+	ID     int    `json:"id" db:"id"`
+	NPI    string `json:"npi" db:"npi"`
+	Chain  string `json:"chain" db:"chain"`
+	Active bool   `json:"active" db:"active"`
+}
+
+type PharmacyRequest struct {
+	NPI    string `json:"npi"`
+	Chain  string `json:"chain"`
+	Active *bool  `json:"active,omitempty"`
+}
+
+type PharmacyFilter struct {
+	Chain  string
+	Active *bool
+}
+
+const (
+	NPIEntityTypeIndividual   = 1
+	NPIEntityTypeOrganization = 2
+)
+
+type NPIRecord struct {
+	NPI              string     `json:"npi" db:"npi"`
+	EntityType       int        `json:"entity_type" db:"entity_type"`
+	Name             string     `json:"name" db:"name"`
+	TaxonomyCodes    []string   `json:"taxonomy_codes" db:"taxonomy_codes"`
+	DeactivationDate *time.Time `json:"deactivation_date,omitempty" db:"deactivation_date"`
+}
+
+type Member struct {
+	ID              int        `json:"id" db:"id"`
+	MemberID        string     `json:"member_id" db:"member_id"`
+	GroupID         string     `json:"group_id" db:"group_id"`
+	PlanID          string     `json:"plan_id" db:"plan_id"`
+	EffectiveDate   time.Time  `json:"effective_date" db:"effective_date"`
+	TerminationDate *time.Time `json:"termination_date,omitempty" db:"termination_date"`
+}
+
+type FormularyEntry struct {
+	ID                  int      `json:"id" db:"id"`
+	PlanID              string   `json:"plan_id" db:"plan_id"`
+	NDC                 string   `json:"ndc" db:"ndc"`
+	Tier                int      `json:"tier" db:"tier"`
+	QuantityLimit       *float64 `json:"quantity_limit,omitempty" db:"quantity_limit"`
+	PriorAuthRequired   bool     `json:"prior_auth_required" db:"prior_auth_required"`
+	StepTherapyRequired bool     `json:"step_therapy_required" db:"step_therapy_required"`
 }
 
 type Claim struct {
-	ID        uuid.UUID  `json:"id" db:"id"`
-	NDC       string     `json:"ndc" db:"ndc"`
-	Quantity  float64    `json:"quantity" db:"quantity"`
-	NPI       string     `json:"npi" db:"npi"`
-	Price     float64    `json:"price" db:"price"`
-	Timestamp CustomTime `json:"timestamp" db:"timestamp"`
+	ID                 uuid.UUID    `json:"id" db:"id"`
+	NDC                string       `json:"ndc" db:"ndc"`
+	Quantity           float64      `json:"quantity" db:"quantity"`
+	DaysSupply         int          `json:"days_supply,omitempty" db:"days_supply"`
+	DAWCode            string       `json:"daw_code,omitempty" db:"daw_code"`
+	FillNumber         int          `json:"fill_number" db:"fill_number"`
+	PrescriptionNumber string       `json:"prescription_number,omitempty" db:"prescription_number"`
+	DateWritten        *time.Time   `json:"date_written,omitempty" db:"date_written"`
+	PrescriberNPI      string       `json:"prescriber_npi,omitempty" db:"prescriber_npi"`
+	NPI                string       `json:"npi" db:"npi"`
+	Price              float64      `json:"price" db:"price"`
+	Timestamp          CustomTime   `json:"timestamp" db:"timestamp"`
+	MemberID           string       `json:"member_id,omitempty" db:"member_id"`
+	ServiceDate        time.Time    `json:"service_date" db:"service_date"`
+	Status             string       `json:"status" db:"status"`
+	Rejects            []Reject     `json:"rejects,omitempty" db:"rejects"`
+	DUR                []DURAlert   `json:"dur,omitempty" db:"dur"`
+	DuplicateOf        *uuid.UUID   `json:"duplicate_of,omitempty" db:"duplicate_of"`
+	OriginalClaimID    *uuid.UUID   `json:"original_claim_id,omitempty" db:"original_claim_id"`
+	PriorAuthID        *int         `json:"prior_auth_id,omitempty" db:"prior_auth_id"`
+	Pricing            ClaimPricing `json:"pricing,omitzero"`
+	CostShare          CostShare    `json:"cost_share,omitzero"`
+}
+
+type ClaimPricing struct {
+	Basis          string  `json:"basis" db:"pricing_basis"`
+	IngredientCost float64 `json:"ingredient_cost" db:"ingredient_cost"`
+	DispensingFee  float64 `json:"dispensing_fee" db:"dispensing_fee"`
+	Total          float64 `json:"total" db:"allowed_amount"`
+	ContractID     *int    `json:"contract_id,omitempty" db:"contract_id"`
+}
+
+type CostShare struct {
+	Deductible  float64 `json:"deductible" db:"deductible_amount"`
+	Copay       float64 `json:"copay" db:"copay_amount"`
+	Coinsurance float64 `json:"coinsurance" db:"coinsurance_amount"`
+	PatientPay  float64 `json:"patient_pay" db:"patient_pay_amount"`
+	PlanPaid    float64 `json:"plan_paid" db:"plan_paid_amount"`
+}
+
+type BenefitPlan struct {
+	PlanID     string            `json:"plan_id" db:"plan_id"`
+	Deductible float64           `json:"deductible" db:"deductible"`
+	OOPMax     *float64          `json:"oop_max,omitempty" db:"oop_max"`
+	Tiers      []BenefitPlanTier `json:"tiers"`
+}
+
+type BenefitPlanTier struct {
+	Tier               int     `json:"tier" db:"tier"`
+	Copay              float64 `json:"copay,omitempty" db:"copay"`
+	CoinsurancePercent float64 `json:"coinsurance_percent,omitempty" db:"coinsurance_percent"`
+}
+
+type Accumulator struct {
+	MemberID      string     `json:"member_id" db:"member_id"`
+	PlanYear      int        `json:"plan_year" db:"plan_year"`
+	DeductibleMet float64    `json:"deductible_met" db:"deductible_amount"`
+	OOPMet        float64    `json:"oop_met" db:"oop_amount"`
+	AsOf          *time.Time `json:"as_of,omitempty"`
+}
+
+type AccumulatorFilter struct {
+	MemberID string
+	PlanYear int
+	AsOf     *time.Time
+}
+
+const (
+	AccumulatorEntryClaim      = "claim"
+	AccumulatorEntryReversal   = "reversal"
+	AccumulatorEntryAdjustment = "adjustment"
+)
+
+type AccumulatorEntry struct {
+	ID         int64      `json:"id" db:"id"`
+	MemberID   string     `json:"member_id" db:"member_id"`
+	PlanYear   int        `json:"plan_year" db:"plan_year"`
+	ClaimID    *uuid.UUID `json:"claim_id,omitempty" db:"claim_id"`
+	EntryType  string     `json:"entry_type" db:"entry_type"`
+	Deductible float64    `json:"deductible" db:"deductible_amount"`
+	OOP        float64    `json:"oop" db:"oop_amount"`
+	PostedAt   time.Time  `json:"posted_at" db:"posted_at"`
+}
+
+const (
+	PriorAuthStatusPending  = "pending"
+	PriorAuthStatusApproved = "approved"
+	PriorAuthStatusDenied   = "denied"
+)
+
+type PriorAuth struct {
+	ID                int       `json:"id" db:"id"`
+	MemberID          string    `json:"member_id" db:"member_id"`
+	NDC               string    `json:"ndc,omitempty" db:"ndc"`
+	DrugClass         string    `json:"drug_class,omitempty" db:"drug_class"`
+	ApprovedQuantity  float64   `json:"approved_quantity" db:"approved_quantity"`
+	UsedQuantity      float64   `json:"used_quantity" db:"used_quantity"`
+	RemainingQuantity float64   `json:"remaining_quantity"`
+	EffectiveFrom     time.Time `json:"effective_from" db:"effective_from"`
+	EffectiveTo       time.Time `json:"effective_to" db:"effective_to"`
+	Status            string    `json:"status" db:"status"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+}
+
+type PriorAuthRequest struct {
+	MemberID         string  `json:"member_id"`
+	NDC              string  `json:"ndc,omitempty"`
+	DrugClass        string  `json:"drug_class,omitempty"`
+	ApprovedQuantity float64 `json:"approved_quantity"`
+	EffectiveFrom    string  `json:"effective_from"`
+	EffectiveTo      string  `json:"effective_to"`
+	Status           string  `json:"status,omitempty"`
+}
+
+const (
+	PriceBasisSubmitted = "submitted"
+	PriceBasisAWP       = "awp"
+	PriceBasisWAC       = "wac"
+	PriceBasisMAC       = "mac"
+	PriceBasisNADAC     = "nadac"
+)
+
+const (
+	ContractActionCreated = "created"
+	ContractActionUpdated = "updated"
+)
+
+type ContractTerms struct {
+	Chain         string     `json:"chain" db:"chain"`
+	Basis         string     `json:"basis" db:"basis"`
+	Percent       float64    `json:"percent" db:"percent"`
+	DispensingFee float64    `json:"dispensing_fee" db:"dispensing_fee"`
+	EffectiveFrom time.Time  `json:"effective_from" db:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty" db:"effective_to"`
+}
+
+type Contract struct {
+	ID int `json:"id" db:"id"`
+	ContractTerms
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+type ContractRequest struct {
+	Chain         string  `json:"chain"`
+	Basis         string  `json:"basis"`
+	Percent       float64 `json:"percent"`
+	DispensingFee float64 `json:"dispensing_fee"`
+	EffectiveFrom string  `json:"effective_from"`
+	EffectiveTo   string  `json:"effective_to,omitempty"`
+	RequestedBy   string  `json:"requested_by,omitempty"`
+}
+
+type ContractFilter struct {
+	Chain string
+	Date  *time.Time
+}
+
+type ContractChange struct {
+	ID         int    `json:"id" db:"id"`
+	ContractID int    `json:"contract_id" db:"contract_id"`
+	Action     string `json:"action" db:"action"`
+	ContractTerms
+	RequestedBy string    `json:"requested_by,omitempty" db:"requested_by"`
+	Timestamp   time.Time `json:"timestamp" db:"timestamp"`
+}
+
+type DrugPrice struct {
+	NDC           string    `json:"ndc" db:"ndc"`
+	AWP           *float64  `json:"awp,omitempty" db:"awp"`
+	WAC           *float64  `json:"wac,omitempty" db:"wac"`
+	MAC           *float64  `json:"mac,omitempty" db:"mac"`
+	NADAC         *float64  `json:"nadac,omitempty" db:"nadac"`
+	EffectiveDate time.Time `json:"effective_date" db:"effective_date"`
+}
+
+const (
+	ClaimStatusPaid     = "paid"
+	ClaimStatusRejected = "rejected"
+)
+
+type Reject struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+const (
+	DURSeverityMajor    = "major"
+	DURSeverityModerate = "moderate"
+	DURSeverityMinor    = "minor"
+)
+
+type DrugClass struct {
+	NDC       string `json:"ndc" db:"ndc"`
+	DrugClass string `json:"drug_class" db:"drug_class"`
+}
+
+type DrugInteraction struct {
+	ID          int    `json:"id" db:"id"`
+	ClassA      string `json:"class_a" db:"class_a"`
+	ClassB      string `json:"class_b" db:"class_b"`
+	Severity    string `json:"severity" db:"severity"`
+	Description string `json:"description" db:"description"`
+}
+
+type DURAlert struct {
+	ReasonCode           string    `json:"reason_code"`
+	ClinicalSignificance string    `json:"clinical_significance,omitempty"`
+	Hard                 bool      `json:"hard"`
+	Message              string    `json:"message"`
+	OtherClaimID         uuid.UUID `json:"other_claim_id"`
+	OtherNDC             string    `json:"other_ndc"`
+	OtherPharmacy        bool      `json:"other_pharmacy"`
+	PreviousFillDate     time.Time `json:"previous_fill_date"`
+	PreviousQuantity     float64   `json:"previous_quantity"`
 }
 
+const (
+	ReversalSourceAPI    = "api"
+	ReversalSourceLoader = "loader"
+	ReversalSourceNCPDP  = "ncpdp"
+)
+
 type Reversal struct {
-	ID        uuid.UUID  `json:"id" db:"id"`
-	ClaimID   uuid.UUID  `json:"claim_id" db:"claim_id"`
-	Timestamp CustomTime `json:"timestamp" db:"timestamp"`
+	ID             uuid.UUID  `json:"id" db:"id"`
+	ClaimID        uuid.UUID  `json:"claim_id" db:"claim_id"`
+	Timestamp      CustomTime `json:"timestamp" db:"timestamp"`
+	Reason         string     `json:"reason,omitempty" db:"reason"`
+	ReasonCode     string     `json:"reason_code,omitempty" db:"reason_code"`
+	RequestedBy    string     `json:"requested_by,omitempty" db:"requested_by"`
+	Source         string     `json:"source,omitempty" db:"source"`
+	WindowOverride bool       `json:"window_override,omitempty" db:"window_override"`
+}
+
+type ClaimDetails struct {
+	Claim
+	Reversed               bool       `json:"reversed"`
+	ReversedAt             *time.Time `json:"reversed_at,omitempty"`
+	ReversalReason         string     `json:"reversal_reason,omitempty"`
+	ReversalReasonCode     string     `json:"reversal_reason_code,omitempty"`
+	ReversedBy             string     `json:"reversed_by,omitempty"`
+	ReversalWindowOverride bool       `json:"reversal_window_override,omitempty"`
+}
+
+type ClaimCursor struct {
+	Timestamp time.Time
+	ID        uuid.UUID
+}
+
+type ClaimSearchFilter struct {
+	NPI                string
+	NDC                string
+	DaysSupply         *int
+	DAWCode            string
+	FillNumber         *int
+	PrescriptionNumber string
+	DateWritten        *time.Time
+	PrescriberNPI      string
+	From               *time.Time
+	To                 *time.Time
+	Status             string
+	Reversed           *bool
+	Cursor             string
+	After              *ClaimCursor
+	Limit              int
+}
+
+type ClaimSearchResponse struct {
+	Claims     []ClaimDetails `json:"claims"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
 type ClaimRequest struct {
-	NDC      string  `json:"ndc"`
-	Quantity float64 `json:"quantity"`
-	NPI      string  `json:"npi"`
-	Price    float64 `json:"price"`
+	NDC                string  `json:"ndc"`
+	Quantity           float64 `json:"quantity"`
+	DaysSupply         int     `json:"days_supply,omitempty"`
+	DAWCode            string  `json:"daw_code,omitempty"`
+	FillNumber         int     `json:"fill_number,omitempty"`
+	PrescriptionNumber string  `json:"prescription_number,omitempty"`
+	DateWritten        string  `json:"date_written,omitempty"`
+	PrescriberNPI      string  `json:"prescriber_npi,omitempty"`
+	NPI                string  `json:"npi"`
+	Price              float64 `json:"price"`
+	MemberID           string  `json:"member_id"`
+	ServiceDate        string  `json:"service_date,omitempty"`
+	IdempotencyKey     string  `json:"-"`
 }
 
 type ClaimResponse struct {
-	Status  string    `json:"status"`
-	ClaimID uuid.UUID `json:"claim_id"`
+	Status      string        `json:"status"`
+	ClaimID     uuid.UUID     `json:"claim_id"`
+	Rejects     []Reject      `json:"rejects,omitempty"`
+	DUR         []DURAlert    `json:"dur,omitempty"`
+	DuplicateOf *uuid.UUID    `json:"duplicate_of,omitempty"`
+	Pricing     *ClaimPricing `json:"pricing,omitempty"`
+	CostShare   *CostShare    `json:"cost_share,omitempty"`
+	PriorAuthID *int          `json:"prior_auth_id,omitempty"`
+}
+
+type IdempotencyRecord struct {
+	Key         string
+	RequestHash string
+	ClaimID     uuid.UUID
+	Response    ClaimResponse
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
 }
 
 type ReversalRequest struct {
-	ClaimID uuid.UUID `json:"claim_id"`
-	Reason  string    `json:"reason,omitempty"`
+	ClaimID     uuid.UUID `json:"claim_id"`
+	NPI         string    `json:"npi"`
+	NDC         string    `json:"ndc,omitempty"`
+	ServiceDate string    `json:"service_date,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	ReasonCode  string    `json:"reason_code,omitempty"`
+	RequestedBy string    `json:"requested_by,omitempty"`
+	Role        string    `json:"-"`
+	Source      string    `json:"-"`
+}
+
+type ReversalMatch struct {
+	NPI                string
+	NDC                string
+	MemberID           string
+	PrescriptionNumber string
+	FillNumber         int
+	ServiceDate        time.Time
 }
 
 type ReversalResponse struct {
-	Status  string    `json:"status"`
-	ClaimID uuid.UUID `json:"claim_id"`
+	Status         string     `json:"status"`
+	ClaimID        uuid.UUID  `json:"claim_id"`
+	ReversalID     uuid.UUID  `json:"reversal_id"`
+	Timestamp      *time.Time `json:"timestamp,omitempty"`
+	Reason         string     `json:"reason,omitempty"`
+	ReasonCode     string     `json:"reason_code,omitempty"`
+	RequestedBy    string     `json:"requested_by,omitempty"`
+	WindowOverride bool       `json:"window_override,omitempty"`
+}
+
+type RebillRequest struct {
+	ClaimID            uuid.UUID `json:"-"`
+	NDC                string    `json:"ndc"`
+	Quantity           float64   `json:"quantity"`
+	DaysSupply         int       `json:"days_supply,omitempty"`
+	DAWCode            string    `json:"daw_code,omitempty"`
+	FillNumber         int       `json:"fill_number,omitempty"`
+	PrescriptionNumber string    `json:"prescription_number,omitempty"`
+	DateWritten        string    `json:"date_written,omitempty"`
+	PrescriberNPI      string    `json:"prescriber_npi,omitempty"`
+	NPI                string    `json:"npi"`
+	Price              float64   `json:"price"`
+	MemberID           string    `json:"member_id"`
+	ServiceDate        string    `json:"service_date,omitempty"`
+	Reason             string    `json:"reason,omitempty"`
+	ReasonCode         string    `json:"reason_code,omitempty"`
+	RequestedBy        string    `json:"requested_by,omitempty"`
+	Role               string    `json:"-"`
+}
+
+type RebillResponse struct {
+	Status          string        `json:"status"`
+	OriginalClaimID uuid.UUID     `json:"original_claim_id"`
+	ClaimID         uuid.UUID     `json:"claim_id"`
+	ReversalID      uuid.UUID     `json:"reversal_id"`
+	DUR             []DURAlert    `json:"dur,omitempty"`
+	DuplicateOf     *uuid.UUID    `json:"duplicate_of,omitempty"`
+	Pricing         *ClaimPricing `json:"pricing,omitempty"`
+	CostShare       *CostShare    `json:"cost_share,omitempty"`
+	PriorAuthID     *int          `json:"prior_auth_id,omitempty"`
 }
 
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
+	Error   string   `json:"error"`
+	Code    string   `json:"code"`
+	Message string   `json:"message,omitempty"`
+	Field   string   `json:"field,omitempty"`
+	Rejects []Reject `json:"rejects,omitempty"`
+}
+
+type MetricsFilter struct {
+	NPI string
+	NDC string
+}
+
+type ClaimMetrics struct {
+	NPI        string  `json:"npi"`
+	NDC        string  `json:"ndc"`
+	Fills      int     `json:"fills"`
+	Reverted   int     `json:"reverted"`
+	AvgPrice   float64 `json:"avg_price"`
+	TotalPrice float64 `json:"total_price"`
+}
+
+type RecommendationFilter struct {
+	NDCs  []string
+	Limit int
+}
+
+type ChainPrice struct {
+	Name     string  `json:"name"`
+	AvgPrice float64 `json:"avg_price"`
+}
+
+type ChainRecommendation struct {
+	NDC    string       `json:"ndc"`
+	Chains []ChainPrice `json:"chain"`
+}
+
+type QuantityFilter struct {
+	NDCs  []string
+	Limit int
+}
+
+type QuantityCount struct {
+	Quantity float64 `json:"quantity"`
+	Count    int     `json:"count"`
+}
+
+type QuantityReport struct {
+	NDC        string          `json:"ndc"`
+	Quantities []QuantityCount `json:"most_prescribed_quantity"`
 }
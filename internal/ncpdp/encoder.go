@@ -0,0 +1,118 @@
+package ncpdp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+func EncodeResponse(response *Response) []byte {
+	var b strings.Builder
+
+	header := response.Header
+	b.WriteString(header.Version)
+	b.WriteString(header.TransactionCode)
+	b.WriteString(strconv.Itoa(header.TransactionCount))
+	b.WriteString(header.Status)
+	b.WriteString(header.ServiceProviderIDQualifier)
+	b.WriteString(fmt.Sprintf("%-15.15s", header.ServiceProviderID))
+	if header.DateOfService.IsZero() {
+		b.WriteString(strings.Repeat(" ", len(DateLayout)))
+	} else {
+		b.WriteString(header.DateOfService.Format(DateLayout))
+	}
+
+	if response.Message != "" {
+		writeSegment(&b, SegmentResponseMessage, "F4", response.Message)
+	}
+
+	for _, transaction := range response.Transactions {
+		b.WriteByte(GroupSeparator)
+
+		fields := []string{"AN", transaction.Status}
+		if transaction.AuthorizationNumber != "" {
+			fields = append(fields, "F3", transaction.AuthorizationNumber)
+		}
+		if len(transaction.RejectCodes) > 0 {
+			fields = append(fields, "FA", strconv.Itoa(len(transaction.RejectCodes)))
+			for _, code := range transaction.RejectCodes {
+				fields = append(fields, "FB", code)
+			}
+		}
+		if transaction.Message != "" {
+			fields = append(fields, "FQ", transaction.Message)
+		}
+		writeSegment(&b, SegmentResponseStatus, fields...)
+
+		if transaction.PrescriptionNumber != "" {
+			writeSegment(&b, SegmentResponseClaim,
+				"EM", transaction.PrescriptionRefQualifier,
+				"D2", transaction.PrescriptionNumber,
+			)
+		}
+
+		var pricing []string
+		if transaction.PatientPayAmount != nil {
+			pricing = append(pricing, "F5", FormatOverpunch(*transaction.PatientPayAmount, 2))
+		}
+		if transaction.IngredientCostPaid != nil {
+			pricing = append(pricing, "F6", FormatOverpunch(*transaction.IngredientCostPaid, 2))
+		}
+		if transaction.DispensingFeePaid != nil {
+			pricing = append(pricing, "F7", FormatOverpunch(*transaction.DispensingFeePaid, 2))
+		}
+		if transaction.AmountAppliedToDeductible != nil {
+			pricing = append(pricing, "FH", FormatOverpunch(*transaction.AmountAppliedToDeductible, 2))
+		}
+		if transaction.AmountOfCopay != nil {
+			pricing = append(pricing, "FI", FormatOverpunch(*transaction.AmountOfCopay, 2))
+		}
+		if transaction.TotalAmountPaid != nil {
+			pricing = append(pricing, "F9", FormatOverpunch(*transaction.TotalAmountPaid, 2))
+		}
+		if transaction.AmountOfCoinsurance != nil {
+			pricing = append(pricing, "4U", FormatOverpunch(*transaction.AmountOfCoinsurance, 2))
+		}
+		if len(pricing) > 0 {
+			writeSegment(&b, SegmentResponsePricing, pricing...)
+		}
+
+		var dur []string
+		for i, alert := range transaction.DUR {
+			dur = append(dur, "J6", strconv.Itoa(i+1), "E4", alert.ReasonCode)
+			if alert.ClinicalSignificance != "" {
+				dur = append(dur, "FS", alert.ClinicalSignificance)
+			}
+			if alert.OtherPharmacyIndicator != "" {
+				dur = append(dur, "FT", alert.OtherPharmacyIndicator)
+			}
+			if !alert.PreviousFillDate.IsZero() {
+				dur = append(dur, "FU", alert.PreviousFillDate.Format(DateLayout))
+			}
+			if alert.PreviousQuantity > 0 {
+				dur = append(dur, "FV", FormatImplied(alert.PreviousQuantity, 3))
+			}
+			if alert.DatabaseIndicator != "" {
+				dur = append(dur, "FW", alert.DatabaseIndicator)
+			}
+			if alert.Message != "" {
+				dur = append(dur, "FY", alert.Message)
+			}
+		}
+		if len(dur) > 0 {
+			writeSegment(&b, SegmentResponseDUR, dur...)
+		}
+	}
+
+	return []byte(b.String())
+}
+
+func writeSegment(b *strings.Builder, id string, fields ...string) {
+	b.WriteByte(SegmentSeparator)
+	b.WriteByte(FieldSeparator)
+	b.WriteString("AM" + id)
+	for i := 0; i+1 < len(fields); i += 2 {
+		b.WriteByte(FieldSeparator)
+		b.WriteString(fields[i] + fields[i+1])
+	}
+}
@@ -0,0 +1,69 @@
+package ncpdp
+
+import (
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/ndc"
+	"pharmacyclaims/internal/utility"
+
+	"github.com/google/uuid"
+)
+
+func (t Transaction) ClaimRequest(header RequestHeader, insurance *Insurance) models.ClaimRequest {
+	request := models.ClaimRequest{
+		NDC:                ndc.Normalize(t.Claim.ProductID),
+		Quantity:           t.Claim.QuantityDispensed,
+		DaysSupply:         t.Claim.DaysSupply,
+		DAWCode:            t.Claim.DispenseAsWritten,
+		FillNumber:         t.Claim.FillNumber,
+		PrescriptionNumber: t.Claim.PrescriptionNumber,
+		NPI:                header.ServiceProviderID,
+		ServiceDate:        header.DateOfService.Format(utility.ServiceDateLayout),
+	}
+
+	if !t.Claim.DatePrescriptionWritten.IsZero() {
+		request.DateWritten = t.Claim.DatePrescriptionWritten.Format(utility.ServiceDateLayout)
+	}
+
+	if t.Prescriber != nil && t.Prescriber.IDQualifier == PrescriberQualifierNPI {
+		request.PrescriberNPI = t.Prescriber.ID
+	}
+
+	if insurance != nil {
+		request.MemberID = insurance.CardholderID
+	}
+
+	if t.Pricing != nil {
+		request.Price = t.Pricing.GrossAmountDue
+		if request.Price == 0 {
+			request.Price = t.Pricing.IngredientCost + t.Pricing.DispensingFee
+		}
+	}
+
+	return request
+}
+
+func (t Transaction) ReversalMatch(header RequestHeader, insurance *Insurance) models.ReversalMatch {
+	match := models.ReversalMatch{
+		NPI:                header.ServiceProviderID,
+		NDC:                ndc.Normalize(t.Claim.ProductID),
+		PrescriptionNumber: t.Claim.PrescriptionNumber,
+		FillNumber:         t.Claim.FillNumber,
+		ServiceDate:        header.DateOfService,
+	}
+
+	if insurance != nil {
+		match.MemberID = insurance.CardholderID
+	}
+
+	return match
+}
+
+func (t Transaction) ReversalRequest(header RequestHeader, claimID uuid.UUID) models.ReversalRequest {
+	return models.ReversalRequest{
+		ClaimID:     claimID,
+		NPI:         header.ServiceProviderID,
+		NDC:         ndc.Normalize(t.Claim.ProductID),
+		ServiceDate: header.DateOfService.Format(utility.ServiceDateLayout),
+		Source:      models.ReversalSourceNCPDP,
+	}
+}
@@ -0,0 +1,225 @@
+package ncpdp
+
+import "time"
+
+const (
+	SegmentSeparator = 0x1E
+	GroupSeparator   = 0x1D
+	FieldSeparator   = 0x1C
+
+	Version = "D0"
+
+	TransactionBilling  = "B1"
+	TransactionReversal = "B2"
+
+	RequestHeaderLength  = 56
+	ResponseHeaderLength = 31
+
+	DateLayout = "20060102"
+
+	ProviderQualifierNPI   = "01"
+	ProductQualifierNDC    = "03"
+	PrescriberQualifierNPI = "01"
+)
+
+const (
+	SegmentPatient    = "01"
+	SegmentPrescriber = "03"
+	SegmentInsurance  = "04"
+	SegmentClaim      = "07"
+	SegmentPricing    = "11"
+
+	SegmentResponseMessage = "20"
+	SegmentResponseStatus  = "21"
+	SegmentResponseClaim   = "22"
+	SegmentResponsePricing = "23"
+	SegmentResponseDUR     = "24"
+)
+
+const (
+	HeaderStatusAccepted = "A"
+	HeaderStatusRejected = "R"
+
+	TransactionStatusPaid     = "P"
+	TransactionStatusApproved = "A"
+	TransactionStatusRejected = "R"
+)
+
+const (
+	RejectPharmacyNumber          = "05"
+	RejectCardholderID            = "07"
+	RejectDateOfService           = "15"
+	RejectPrescriptionNumber      = "16"
+	RejectFillNumber              = "17"
+	RejectDaysSupply              = "19"
+	RejectProductID               = "21"
+	RejectDispenseAsWritten       = "22"
+	RejectPrescriberID            = "25"
+	RejectDatePrescriptionWritten = "28"
+	RejectPharmacyNotContracted   = "40"
+	RejectNonMatchedCardholderID  = "52"
+	RejectBeforeCoverageEffective = "67"
+	RejectProductNotCovered       = "70"
+	RejectPriorAuthRequired       = "75"
+	RejectAfterCoverageTerminated = "69"
+	RejectPlanLimitsExceeded      = "76"
+	RejectCostExceedsMaximum      = "78"
+	RejectRefillTooSoon           = "79"
+	RejectClaimTooOld             = "81"
+	RejectDuplicateClaim          = "83"
+	RejectClaimNotProcessed       = "85"
+	RejectReversalNotProcessed    = "87"
+	RejectDURReject               = "88"
+	RejectHostProcessingError     = "99"
+	RejectStepTherapyRequired     = "608"
+	RejectVersionNotSupported     = "1R"
+	RejectTransactionNotSupported = "1S"
+	RejectTransactionCount        = "A9"
+	RejectProviderIDQualifier     = "B2"
+	RejectProductIDQualifier      = "E1"
+	RejectQuantityDispensed       = "E7"
+	RejectGrossAmountDue          = "DU"
+)
+
+const (
+	DURReasonDrugInteraction        = "DD"
+	DURReasonTherapeuticDuplication = "TD"
+	DURReasonEarlyRefill            = "ER"
+
+	ClinicalSignificanceMajor    = "1"
+	ClinicalSignificanceModerate = "2"
+	ClinicalSignificanceMinor    = "3"
+
+	OtherPharmacySame  = "1"
+	OtherPharmacyOther = "3"
+
+	DURDatabaseProcessor = "4"
+)
+
+type RequestHeader struct {
+	BIN                        string
+	Version                    string
+	TransactionCode            string
+	ProcessorControlNumber     string
+	TransactionCount           int
+	ServiceProviderIDQualifier string
+	ServiceProviderID          string
+	DateOfService              time.Time
+	SoftwareVendorID           string
+}
+
+type Patient struct {
+	PatientID   string
+	DateOfBirth string
+	Gender      string
+	FirstName   string
+	LastName    string
+}
+
+type Insurance struct {
+	CardholderID string
+	GroupID      string
+}
+
+type Claim struct {
+	PrescriptionRefQualifier string
+	PrescriptionNumber       string
+	ProductIDQualifier       string
+	ProductID                string
+	QuantityDispensed        float64
+	FillNumber               int
+	DaysSupply               int
+	DispenseAsWritten        string
+	DatePrescriptionWritten  time.Time
+}
+
+type Pricing struct {
+	IngredientCost    float64
+	DispensingFee     float64
+	UsualAndCustomary float64
+	GrossAmountDue    float64
+}
+
+type Prescriber struct {
+	IDQualifier string
+	ID          string
+}
+
+type Transaction struct {
+	Claim      Claim
+	Pricing    *Pricing
+	Prescriber *Prescriber
+}
+
+type Request struct {
+	Header       RequestHeader
+	Patient      *Patient
+	Insurance    *Insurance
+	Transactions []Transaction
+}
+
+type ResponseHeader struct {
+	Version                    string
+	TransactionCode            string
+	TransactionCount           int
+	Status                     string
+	ServiceProviderIDQualifier string
+	ServiceProviderID          string
+	DateOfService              time.Time
+}
+
+type TransactionResponse struct {
+	Status                    string
+	AuthorizationNumber       string
+	RejectCodes               []string
+	Message                   string
+	PrescriptionRefQualifier  string
+	PrescriptionNumber        string
+	PatientPayAmount          *float64
+	IngredientCostPaid        *float64
+	DispensingFeePaid         *float64
+	AmountAppliedToDeductible *float64
+	AmountOfCopay             *float64
+	TotalAmountPaid           *float64
+	AmountOfCoinsurance       *float64
+	DUR                       []DURResponse
+}
+
+type DURResponse struct {
+	ReasonCode             string
+	ClinicalSignificance   string
+	OtherPharmacyIndicator string
+	PreviousFillDate       time.Time
+	PreviousQuantity       float64
+	DatabaseIndicator      string
+	Message                string
+}
+
+type Response struct {
+	Header       ResponseHeader
+	Message      string
+	Transactions []TransactionResponse
+}
+
+type ParseError struct {
+	RejectCode string
+	Message    string
+}
+
+func (e *ParseError) Error() string {
+	return e.Message
+}
+
+func NewResponse(header RequestHeader) *Response {
+	return &Response{
+		Header: ResponseHeader{
+			Version:                    Version,
+			TransactionCode:            header.TransactionCode,
+			TransactionCount:           header.TransactionCount,
+			Status:                     HeaderStatusAccepted,
+			ServiceProviderIDQualifier: header.ServiceProviderIDQualifier,
+			ServiceProviderID:          header.ServiceProviderID,
+			DateOfService:              header.DateOfService,
+		},
+	}
+}
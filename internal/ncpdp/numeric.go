@@ -0,0 +1,68 @@
+package ncpdp
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	positiveOverpunch = "{ABCDEFGHI"
+	negativeOverpunch = "}JKLMNOPQR"
+)
+
+func ParseOverpunch(value string, decimals int) (float64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("empty signed numeric")
+	}
+
+	last := value[len(value)-1]
+	sign := 1.0
+	digit := byte(0)
+
+	if i := strings.IndexByte(positiveOverpunch, last); i >= 0 {
+		digit = '0' + byte(i)
+	} else if i := strings.IndexByte(negativeOverpunch, last); i >= 0 {
+		digit = '0' + byte(i)
+		sign = -1
+	} else if last >= '0' && last <= '9' {
+		digit = last
+	} else {
+		return 0, fmt.Errorf("invalid signed numeric %q", value)
+	}
+
+	digits := value[:len(value)-1] + string(digit)
+	n, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid signed numeric %q", value)
+	}
+
+	return sign * float64(n) / math.Pow10(decimals), nil
+}
+
+func FormatOverpunch(amount float64, decimals int) string {
+	n := int64(math.Round(amount * math.Pow10(decimals)))
+	table := positiveOverpunch
+	if n < 0 {
+		n = -n
+		table = negativeOverpunch
+	}
+
+	digits := strconv.FormatInt(n, 10)
+	last := digits[len(digits)-1] - '0'
+	return digits[:len(digits)-1] + string(table[last])
+}
+
+func ParseImplied(value string, decimals int) (float64, error) {
+	n, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid numeric %q", value)
+	}
+	return float64(n) / math.Pow10(decimals), nil
+}
+
+func FormatImplied(value float64, decimals int) string {
+	return strconv.FormatInt(int64(math.Round(value*math.Pow10(decimals))), 10)
+}
@@ -0,0 +1,256 @@
+package ncpdp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type segment struct {
+	id     string
+	fields map[string]string
+}
+
+func ParseRequest(data []byte) (*Request, error) {
+	if len(data) < RequestHeaderLength {
+		return nil, fmt.Errorf("request must be at least %d bytes, got %d", RequestHeaderLength, len(data))
+	}
+
+	request := &Request{}
+	if err := parseRequestHeader(string(data[:RequestHeaderLength]), &request.Header); err != nil {
+		return request, err
+	}
+
+	groups := strings.Split(string(data[RequestHeaderLength:]), string(rune(GroupSeparator)))
+
+	segments, err := parseSegments(groups[0])
+	if err != nil {
+		return request, err
+	}
+	for _, seg := range segments {
+		switch seg.id {
+		case SegmentPatient:
+			request.Patient = parsePatient(seg)
+		case SegmentInsurance:
+			request.Insurance = parseInsurance(seg)
+		}
+	}
+
+	for _, group := range groups[1:] {
+		transaction, err := parseTransaction(group)
+		if err != nil {
+			return request, err
+		}
+		request.Transactions = append(request.Transactions, *transaction)
+	}
+
+	if len(request.Transactions) != request.Header.TransactionCount {
+		return request, &ParseError{
+			RejectCode: RejectTransactionCount,
+			Message:    fmt.Sprintf("header transaction count %d does not match %d transactions", request.Header.TransactionCount, len(request.Transactions)),
+		}
+	}
+
+	return request, nil
+}
+
+func parseRequestHeader(raw string, header *RequestHeader) error {
+	header.BIN = strings.TrimSpace(raw[0:6])
+	header.Version = raw[6:8]
+	header.TransactionCode = raw[8:10]
+	header.ProcessorControlNumber = strings.TrimSpace(raw[10:20])
+	header.ServiceProviderIDQualifier = raw[21:23]
+	header.ServiceProviderID = strings.TrimSpace(raw[23:38])
+	header.SoftwareVendorID = strings.TrimSpace(raw[46:56])
+
+	if header.Version != Version {
+		return &ParseError{RejectCode: RejectVersionNotSupported, Message: fmt.Sprintf("version %q not supported", header.Version)}
+	}
+
+	if header.TransactionCode != TransactionBilling && header.TransactionCode != TransactionReversal {
+		return &ParseError{RejectCode: RejectTransactionNotSupported, Message: fmt.Sprintf("transaction code %q not supported", header.TransactionCode)}
+	}
+
+	count, err := strconv.Atoi(raw[20:21])
+	if err != nil || count < 1 || count > 4 {
+		return &ParseError{RejectCode: RejectTransactionCount, Message: fmt.Sprintf("invalid transaction count %q", raw[20:21])}
+	}
+	header.TransactionCount = count
+
+	if header.ServiceProviderIDQualifier != ProviderQualifierNPI {
+		return &ParseError{RejectCode: RejectProviderIDQualifier, Message: fmt.Sprintf("service provider ID qualifier %q not supported", header.ServiceProviderIDQualifier)}
+	}
+
+	dateOfService, err := time.Parse(DateLayout, raw[38:46])
+	if err != nil {
+		return &ParseError{RejectCode: RejectDateOfService, Message: fmt.Sprintf("invalid date of service %q", raw[38:46])}
+	}
+	header.DateOfService = dateOfService
+
+	return nil
+}
+
+func parseSegments(group string) ([]segment, error) {
+	var segments []segment
+
+	for _, raw := range strings.Split(group, string(rune(SegmentSeparator))) {
+		if raw == "" {
+			continue
+		}
+
+		parts := strings.Split(raw, string(rune(FieldSeparator)))
+		if len(parts) < 2 || parts[0] != "" || len(parts[1]) != 4 || !strings.HasPrefix(parts[1], "AM") {
+			return nil, &ParseError{RejectCode: RejectClaimNotProcessed, Message: fmt.Sprintf("malformed segment %q", raw)}
+		}
+
+		seg := segment{id: parts[1][2:], fields: make(map[string]string)}
+		for _, field := range parts[2:] {
+			if len(field) < 2 {
+				return nil, &ParseError{RejectCode: RejectClaimNotProcessed, Message: fmt.Sprintf("malformed field %q in segment AM%s", field, seg.id)}
+			}
+			if _, exists := seg.fields[field[:2]]; !exists {
+				seg.fields[field[:2]] = field[2:]
+			}
+		}
+
+		segments = append(segments, seg)
+	}
+
+	return segments, nil
+}
+
+func parseTransaction(group string) (*Transaction, error) {
+	segments, err := parseSegments(group)
+	if err != nil {
+		return nil, err
+	}
+
+	transaction := &Transaction{}
+	hasClaim := false
+
+	for _, seg := range segments {
+		switch seg.id {
+		case SegmentClaim:
+			claim, err := parseClaim(seg)
+			if err != nil {
+				return nil, err
+			}
+			transaction.Claim = *claim
+			hasClaim = true
+		case SegmentPricing:
+			pricing, err := parsePricing(seg)
+			if err != nil {
+				return nil, err
+			}
+			transaction.Pricing = pricing
+		case SegmentPrescriber:
+			transaction.Prescriber = &Prescriber{
+				IDQualifier: seg.fields["EZ"],
+				ID:          strings.TrimSpace(seg.fields["DB"]),
+			}
+		}
+	}
+
+	if !hasClaim {
+		return nil, &ParseError{RejectCode: RejectClaimNotProcessed, Message: "transaction is missing the claim segment"}
+	}
+
+	return transaction, nil
+}
+
+func parsePatient(seg segment) *Patient {
+	return &Patient{
+		PatientID:   strings.TrimSpace(seg.fields["CY"]),
+		DateOfBirth: seg.fields["C4"],
+		Gender:      seg.fields["C5"],
+		FirstName:   strings.TrimSpace(seg.fields["CA"]),
+		LastName:    strings.TrimSpace(seg.fields["CB"]),
+	}
+}
+
+func parseInsurance(seg segment) *Insurance {
+	return &Insurance{
+		CardholderID: strings.TrimSpace(seg.fields["C2"]),
+		GroupID:      strings.TrimSpace(seg.fields["C1"]),
+	}
+}
+
+func parseClaim(seg segment) (*Claim, error) {
+	claim := &Claim{
+		PrescriptionRefQualifier: seg.fields["EM"],
+		PrescriptionNumber:       strings.TrimSpace(seg.fields["D2"]),
+		ProductIDQualifier:       seg.fields["E1"],
+		ProductID:                strings.TrimSpace(seg.fields["D7"]),
+		DispenseAsWritten:        strings.TrimSpace(seg.fields["D8"]),
+	}
+
+	if claim.ProductIDQualifier != ProductQualifierNDC {
+		return nil, &ParseError{RejectCode: RejectProductIDQualifier, Message: fmt.Sprintf("product ID qualifier %q not supported", claim.ProductIDQualifier)}
+	}
+	if claim.ProductID == "" {
+		return nil, &ParseError{RejectCode: RejectProductID, Message: "missing product ID"}
+	}
+
+	if value, ok := seg.fields["E7"]; ok {
+		quantity, err := ParseImplied(value, 3)
+		if err != nil {
+			return nil, &ParseError{RejectCode: RejectQuantityDispensed, Message: err.Error()}
+		}
+		claim.QuantityDispensed = quantity
+	}
+
+	if value, ok := seg.fields["D3"]; ok {
+		fill, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, &ParseError{RejectCode: RejectFillNumber, Message: fmt.Sprintf("invalid fill number %q", value)}
+		}
+		claim.FillNumber = fill
+	}
+
+	if value, ok := seg.fields["D5"]; ok {
+		days, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, &ParseError{RejectCode: RejectDaysSupply, Message: fmt.Sprintf("invalid days supply %q", value)}
+		}
+		claim.DaysSupply = days
+	}
+
+	if value, ok := seg.fields["DE"]; ok {
+		written, err := time.Parse(DateLayout, value)
+		if err != nil {
+			return nil, &ParseError{RejectCode: RejectDatePrescriptionWritten, Message: fmt.Sprintf("invalid date prescription written %q", value)}
+		}
+		claim.DatePrescriptionWritten = written
+	}
+
+	return claim, nil
+}
+
+func parsePricing(seg segment) (*Pricing, error) {
+	pricing := &Pricing{}
+
+	amounts := []struct {
+		id     string
+		target *float64
+	}{
+		{"D9", &pricing.IngredientCost},
+		{"DC", &pricing.DispensingFee},
+		{"DQ", &pricing.UsualAndCustomary},
+		{"DU", &pricing.GrossAmountDue},
+	}
+
+	for _, amount := range amounts {
+		value, ok := seg.fields[amount.id]
+		if !ok {
+			continue
+		}
+		parsed, err := ParseOverpunch(value, 2)
+		if err != nil {
+			return nil, &ParseError{RejectCode: amount.id, Message: err.Error()}
+		}
+		*amount.target = parsed
+	}
+
+	return pricing, nil
+}
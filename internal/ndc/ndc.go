@@ -0,0 +1,84 @@
+package ndc
+
+import (
+	"errors"
+	"strings"
+)
+
+const Length = 11
+
+var (
+	ErrNotNumeric = errors.New("invalid NDC format: must be numeric")
+	ErrFormat     = errors.New("invalid NDC format: must be 11 digits or hyphenated 4-4-2, 5-3-2, 5-4-1 or 5-4-2")
+)
+
+type NDC struct {
+	Labeler string
+	Product string
+	Package string
+}
+
+func Parse(value string) (NDC, error) {
+	value = strings.TrimSpace(value)
+	segments := strings.Split(value, "-")
+	for _, segment := range segments {
+		if !numeric(segment) {
+			if segment == "" {
+				return NDC{}, ErrFormat
+			}
+			return NDC{}, ErrNotNumeric
+		}
+	}
+
+	switch len(segments) {
+	case 1:
+		if len(value) != Length {
+			return NDC{}, ErrFormat
+		}
+		return NDC{Labeler: value[:5], Product: value[5:9], Package: value[9:]}, nil
+	case 3:
+		code := NDC{Labeler: segments[0], Product: segments[1], Package: segments[2]}
+		switch [3]int{len(code.Labeler), len(code.Product), len(code.Package)} {
+		case [3]int{4, 4, 2}:
+			code.Labeler = "0" + code.Labeler
+		case [3]int{5, 3, 2}:
+			code.Product = "0" + code.Product
+		case [3]int{5, 4, 1}:
+			code.Package = "0" + code.Package
+		case [3]int{5, 4, 2}:
+		default:
+			return NDC{}, ErrFormat
+		}
+		return code, nil
+	default:
+		return NDC{}, ErrFormat
+	}
+}
+
+func Normalize(value string) string {
+	code, err := Parse(value)
+	if err != nil {
+		return value
+	}
+	return code.String()
+}
+
+func (n NDC) String() string {
+	return n.Labeler + n.Product + n.Package
+}
+
+func (n NDC) Hyphenated() string {
+	return n.Labeler + "-" + n.Product + "-" + n.Package
+}
+
+func numeric(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
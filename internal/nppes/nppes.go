@@ -0,0 +1,164 @@
+package nppes
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"pharmacyclaims/internal/models"
+)
+
+const (
+	NPILength = 10
+
+	npiPrefix             = "80840"
+	pharmacyTaxonomyGroup = "3336"
+	dateLayout            = "01/02/2006"
+)
+
+const (
+	ColumnNPI              = "NPI"
+	ColumnEntityType       = "Entity Type Code"
+	ColumnOrganizationName = "Provider Organization Name (Legal Business Name)"
+	ColumnLastName         = "Provider Last Name (Legal Name)"
+	ColumnFirstName        = "Provider First Name"
+	ColumnDeactivationDate = "NPI Deactivation Date"
+	ColumnReactivationDate = "NPI Reactivation Date"
+	ColumnTaxonomyPrefix   = "Healthcare Provider Taxonomy Code_"
+)
+
+var ErrInvalidRecord = errors.New("invalid NPPES record")
+
+func CheckDigit(base string) int {
+	sum := 0
+	digits := npiPrefix + base
+	for i := len(digits) - 1; i >= 0; i-- {
+		digit := int(digits[i] - '0')
+		if (len(digits)-i)%2 == 1 {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+	}
+	return (10 - sum%10) % 10
+}
+
+func ValidNPI(npi string) bool {
+	if len(npi) != NPILength {
+		return false
+	}
+	for _, r := range npi {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return CheckDigit(npi[:NPILength-1]) == int(npi[NPILength-1]-'0')
+}
+
+func IsPharmacyTaxonomy(code string) bool {
+	return strings.HasPrefix(code, pharmacyTaxonomyGroup)
+}
+
+type Reader struct {
+	csv        *csv.Reader
+	columns    map[string]int
+	taxonomies []int
+}
+
+func NewReader(r io.Reader) (*Reader, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NPPES header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	var taxonomies []int
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		columns[name] = i
+		if strings.HasPrefix(name, ColumnTaxonomyPrefix) {
+			taxonomies = append(taxonomies, i)
+		}
+	}
+
+	for _, required := range []string{ColumnNPI, ColumnEntityType} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing NPPES column %q", required)
+		}
+	}
+
+	return &Reader{csv: reader, columns: columns, taxonomies: taxonomies}, nil
+}
+
+func (r *Reader) Read() (*models.NPIRecord, error) {
+	row, err := r.csv.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	record := &models.NPIRecord{NPI: r.field(row, ColumnNPI)}
+	if !ValidNPI(record.NPI) {
+		return nil, fmt.Errorf("%w: NPI %q fails the check digit", ErrInvalidRecord, record.NPI)
+	}
+
+	if value := r.field(row, ColumnEntityType); value != "" {
+		record.EntityType, err = strconv.Atoi(value)
+		if err != nil || (record.EntityType != models.NPIEntityTypeIndividual && record.EntityType != models.NPIEntityTypeOrganization) {
+			return nil, fmt.Errorf("%w: NPI %s has entity type %q", ErrInvalidRecord, record.NPI, value)
+		}
+	}
+
+	record.Name = r.field(row, ColumnOrganizationName)
+	if record.EntityType == models.NPIEntityTypeIndividual {
+		record.Name = strings.TrimSpace(r.field(row, ColumnFirstName) + " " + r.field(row, ColumnLastName))
+	}
+
+	for _, i := range r.taxonomies {
+		if i < len(row) {
+			if code := strings.TrimSpace(row[i]); code != "" {
+				record.TaxonomyCodes = append(record.TaxonomyCodes, code)
+			}
+		}
+	}
+
+	deactivated, err := r.date(row, ColumnDeactivationDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: NPI %s: %v", ErrInvalidRecord, record.NPI, err)
+	}
+	reactivated, err := r.date(row, ColumnReactivationDate)
+	if err != nil {
+		return nil, fmt.Errorf("%w: NPI %s: %v", ErrInvalidRecord, record.NPI, err)
+	}
+	if deactivated != nil && (reactivated == nil || reactivated.Before(*deactivated)) {
+		record.DeactivationDate = deactivated
+	}
+
+	return record, nil
+}
+
+func (r *Reader) field(row []string, column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+func (r *Reader) date(row []string, column string) (*time.Time, error) {
+	value := r.field(row, column)
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q", column, value)
+	}
+	return &date, nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"pharmacyclaims/internal/models"
+)
+
+const accumulatorEntryColumns = `id, member_id, plan_year, claim_id, entry_type, deductible_amount, oop_amount, posted_at`
+
+func accumulatorEntryFields(entry *models.AccumulatorEntry) []interface{} {
+	return []interface{}{
+		&entry.ID,
+		&entry.MemberID,
+		&entry.PlanYear,
+		&entry.ClaimID,
+		&entry.EntryType,
+		&entry.Deductible,
+		&entry.OOP,
+		&entry.PostedAt,
+	}
+}
+
+type CostShareFunc func(accumulator models.Accumulator) models.CostShare
+
+func (pr *Postgres) GetAccumulator(filter models.AccumulatorFilter) (*models.Accumulator, error) {
+	return getAccumulator(pr.db, filter)
+}
+
+func getAccumulator(q querier, filter models.AccumulatorFilter) (*models.Accumulator, error) {
+	query := `
+		SELECT COALESCE(SUM(deductible_amount), 0), COALESCE(SUM(oop_amount), 0)
+		FROM accumulator_entries
+		WHERE member_id = $1
+			AND plan_year = $2
+			AND ($3::date IS NULL OR posted_at < $3::date + 1)`
+
+	accumulator := &models.Accumulator{
+		MemberID: filter.MemberID,
+		PlanYear: filter.PlanYear,
+		AsOf:     filter.AsOf,
+	}
+
+	var asOf sql.NullTime
+	if filter.AsOf != nil {
+		asOf = sql.NullTime{Time: *filter.AsOf, Valid: true}
+	}
+
+	err := q.QueryRow(query, filter.MemberID, filter.PlanYear, asOf).Scan(&accumulator.DeductibleMet, &accumulator.OOPMet)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accumulator: %w", err)
+	}
+
+	return accumulator, nil
+}
+
+func lockAccumulator(q querier, memberID string, planYear int) error {
+	if _, err := q.Exec(`SELECT pg_advisory_xact_lock(hashtext($1))`, "accumulators:"+memberID+":"+strconv.Itoa(planYear)); err != nil {
+		return fmt.Errorf("failed to lock accumulator: %w", err)
+	}
+	return nil
+}
+
+func applyCostShare(q querier, claim *models.Claim, costShare CostShareFunc) error {
+	planYear := claim.ServiceDate.Year()
+	if err := lockAccumulator(q, claim.MemberID, planYear); err != nil {
+		return err
+	}
+
+	accumulator, err := getAccumulator(q, models.AccumulatorFilter{MemberID: claim.MemberID, PlanYear: planYear})
+	if err != nil {
+		return err
+	}
+
+	claim.CostShare = costShare(*accumulator)
+	return nil
+}
+
+func insertAccumulatorEntry(q querier, entry *models.AccumulatorEntry) error {
+	if entry.Deductible == 0 && entry.OOP == 0 {
+		return nil
+	}
+
+	query := `
+		INSERT INTO accumulator_entries (member_id, plan_year, claim_id, entry_type, deductible_amount, oop_amount, posted_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id`
+
+	err := q.QueryRow(query,
+		entry.MemberID,
+		entry.PlanYear,
+		entry.ClaimID,
+		entry.EntryType,
+		entry.Deductible,
+		entry.OOP,
+		entry.PostedAt,
+	).Scan(&entry.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record accumulator entry: %w", err)
+	}
+
+	return nil
+}
+
+func (pr *Postgres) RecomputeAccumulators(filter models.AccumulatorFilter, postedAt time.Time) ([]models.AccumulatorEntry, error) {
+	query := `
+		WITH expected AS (
+			SELECT c.member_id, EXTRACT(YEAR FROM c.service_date)::int AS plan_year,
+				SUM(c.deductible_amount) AS deductible, SUM(c.patient_pay_amount) AS oop
+			FROM claims c
+			WHERE c.status = 'paid'
+				AND (c.deductible_amount <> 0 OR c.patient_pay_amount <> 0)
+				AND NOT EXISTS (SELECT 1 FROM reversals r WHERE r.claim_id = c.id)
+				AND ($1 = '' OR c.member_id = $1)
+				AND ($2 = 0 OR EXTRACT(YEAR FROM c.service_date)::int = $2)
+			GROUP BY 1, 2
+		),
+		ledger AS (
+			SELECT member_id, plan_year::int AS plan_year,
+				SUM(deductible_amount) AS deductible, SUM(oop_amount) AS oop
+			FROM accumulator_entries
+			WHERE ($1 = '' OR member_id = $1)
+				AND ($2 = 0 OR plan_year = $2)
+			GROUP BY 1, 2
+		),
+		drift AS (
+			SELECT COALESCE(e.member_id, l.member_id) AS member_id,
+				COALESCE(e.plan_year, l.plan_year) AS plan_year,
+				COALESCE(e.deductible, 0) - COALESCE(l.deductible, 0) AS deductible,
+				COALESCE(e.oop, 0) - COALESCE(l.oop, 0) AS oop
+			FROM expected e
+			FULL OUTER JOIN ledger l ON l.member_id = e.member_id AND l.plan_year = e.plan_year
+		)
+		INSERT INTO accumulator_entries (member_id, plan_year, entry_type, deductible_amount, oop_amount, posted_at)
+		SELECT member_id, plan_year, $3, deductible, oop, $4
+		FROM drift
+		WHERE deductible <> 0 OR oop <> 0
+		RETURNING ` + accumulatorEntryColumns
+
+	rows, err := pr.db.Query(query, filter.MemberID, filter.PlanYear, models.AccumulatorEntryAdjustment, postedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recompute accumulators: %w", err)
+	}
+	defer rows.Close()
+
+	adjustments := []models.AccumulatorEntry{}
+	for rows.Next() {
+		var entry models.AccumulatorEntry
+		if err := rows.Scan(accumulatorEntryFields(&entry)...); err != nil {
+			return nil, fmt.Errorf("failed to scan accumulator adjustment: %w", err)
+		}
+		adjustments = append(adjustments, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate accumulator adjustments: %w", err)
+	}
+
+	return adjustments, nil
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"pharmacyclaims/internal/models"
+)
+
+func (pr *Postgres) GetBenefitPlan(planID string) (*models.BenefitPlan, error) {
+	query := `
+		SELECT plan_id, deductible, oop_max
+		FROM benefit_plans
+		WHERE plan_id = $1`
+
+	plan := &models.BenefitPlan{}
+	err := pr.db.QueryRow(query, planID).Scan(&plan.PlanID, &plan.Deductible, &plan.OOPMax)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: plan_id %s", models.ErrPlanNotFound, planID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get benefit plan: %w", err)
+	}
+
+	tierQuery := `
+		SELECT tier, copay, coinsurance_percent
+		FROM benefit_plan_tiers
+		WHERE plan_id = $1
+		ORDER BY tier`
+
+	rows, err := pr.db.Query(tierQuery, planID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get benefit plan tiers: %w", err)
+	}
+	defer rows.Close()
+
+	plan.Tiers = []models.BenefitPlanTier{}
+	for rows.Next() {
+		var tier models.BenefitPlanTier
+		if err := rows.Scan(&tier.Tier, &tier.Copay, &tier.CoinsurancePercent); err != nil {
+			return nil, fmt.Errorf("failed to scan benefit plan tier: %w", err)
+		}
+		plan.Tiers = append(plan.Tiers, tier)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate benefit plan tiers: %w", err)
+	}
+
+	return plan, nil
+}
+
+func (pr *Postgres) BatchCreateBenefitPlans(plans []models.BenefitPlan) error {
+	columns := []string{"plan_id", "deductible", "oop_max"}
+	values := make([][]interface{}, len(plans))
+
+	var tierValues [][]interface{}
+	for i, plan := range plans {
+		values[i] = []interface{}{plan.PlanID, plan.Deductible, plan.OOPMax}
+		for _, tier := range plan.Tiers {
+			tierValues = append(tierValues, []interface{}{plan.PlanID, tier.Tier, tier.Copay, tier.CoinsurancePercent})
+		}
+	}
+
+	if err := pr.batchInsert("benefit_plans", columns, values); err != nil {
+		return err
+	}
+	if len(tierValues) == 0 {
+		return nil
+	}
+
+	return pr.batchInsert("benefit_plan_tiers", []string{"plan_id", "tier", "copay", "coinsurance_percent"}, tierValues)
+}
+
+func (pr *Postgres) CountBenefitPlans() (int, error) {
+	return pr.countRows("benefit_plans")
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"pharmacyclaims/internal/models"
+)
+
+const contractColumns = `id, chain, basis, percent, dispensing_fee, effective_from, effective_to, created_at, updated_at`
+
+func contractFields(contract *models.Contract) []interface{} {
+	return []interface{}{
+		&contract.ID,
+		&contract.Chain,
+		&contract.Basis,
+		&contract.Percent,
+		&contract.DispensingFee,
+		&contract.EffectiveFrom,
+		&contract.EffectiveTo,
+		&contract.CreatedAt,
+		&contract.UpdatedAt,
+	}
+}
+
+func (pr *Postgres) ListContracts(filter models.ContractFilter) ([]models.Contract, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Chain != "" {
+		args = append(args, filter.Chain)
+		conditions = append(conditions, fmt.Sprintf("chain = $%d", len(args)))
+	}
+	if filter.Date != nil {
+		args = append(args, *filter.Date)
+		conditions = append(conditions, fmt.Sprintf("effective_from <= $%d AND (effective_to IS NULL OR effective_to >= $%d)", len(args), len(args)))
+	}
+
+	query := `
+		SELECT ` + contractColumns + `
+		FROM chain_contracts`
+	if len(conditions) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(conditions, " AND ")
+	}
+	query += "\n\t\tORDER BY chain, effective_from"
+
+	rows, err := pr.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contracts: %w", err)
+	}
+	defer rows.Close()
+
+	contracts := []models.Contract{}
+	for rows.Next() {
+		var contract models.Contract
+		if err := rows.Scan(contractFields(&contract)...); err != nil {
+			return nil, fmt.Errorf("failed to scan contract: %w", err)
+		}
+		contracts = append(contracts, contract)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate contracts: %w", err)
+	}
+
+	return contracts, nil
+}
+
+func (pr *Postgres) GetContract(id int) (*models.Contract, error) {
+	query := `
+		SELECT ` + contractColumns + `
+		FROM chain_contracts
+		WHERE id = $1`
+
+	contract := &models.Contract{}
+	err := pr.db.QueryRow(query, id).Scan(contractFields(contract)...)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: id %d", models.ErrContractNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract: %w", err)
+	}
+
+	return contract, nil
+}
+
+func (pr *Postgres) GetContractForChain(chain string, serviceDate time.Time) (*models.Contract, error) {
+	query := `
+		SELECT ` + contractColumns + `
+		FROM chain_contracts
+		WHERE chain = $1
+			AND effective_from <= $2
+			AND (effective_to IS NULL OR effective_to >= $2)
+		ORDER BY effective_from DESC
+		LIMIT 1`
+
+	contract := &models.Contract{}
+	err := pr.db.QueryRow(query, chain, serviceDate).Scan(contractFields(contract)...)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: chain %s on %s", models.ErrContractNotFound, chain, serviceDate.Format(time.DateOnly))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract for chain: %w", err)
+	}
+
+	return contract, nil
+}
+
+func (pr *Postgres) CreateContract(contract *models.Contract, requestedBy string) error {
+	return pr.db.ExecuteInTransaction(func(tx *sql.Tx) error {
+		if err := checkContractOverlap(tx, contract); err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO chain_contracts (chain, basis, percent, dispensing_fee, effective_from, effective_to)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, created_at, updated_at`
+
+		err := tx.QueryRow(query,
+			contract.Chain,
+			contract.Basis,
+			contract.Percent,
+			contract.DispensingFee,
+			contract.EffectiveFrom,
+			contract.EffectiveTo,
+		).Scan(&contract.ID, &contract.CreatedAt, &contract.UpdatedAt)
+		if err != nil {
+			return fmt.Errorf("failed to create contract: %w", err)
+		}
+
+		return insertContractChange(tx, contract, models.ContractActionCreated, requestedBy)
+	})
+}
+
+func (pr *Postgres) UpdateContract(contract *models.Contract, requestedBy string) error {
+	return pr.db.ExecuteInTransaction(func(tx *sql.Tx) error {
+		if err := checkContractOverlap(tx, contract); err != nil {
+			return err
+		}
+
+		query := `
+			UPDATE chain_contracts
+			SET chain = $2, basis = $3, percent = $4, dispensing_fee = $5,
+				effective_from = $6, effective_to = $7, updated_at = NOW()
+			WHERE id = $1
+			RETURNING created_at, updated_at`
+
+		err := tx.QueryRow(query,
+			contract.ID,
+			contract.Chain,
+			contract.Basis,
+			contract.Percent,
+			contract.DispensingFee,
+			contract.EffectiveFrom,
+			contract.EffectiveTo,
+		).Scan(&contract.CreatedAt, &contract.UpdatedAt)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: id %d", models.ErrContractNotFound, contract.ID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update contract: %w", err)
+		}
+
+		return insertContractChange(tx, contract, models.ContractActionUpdated, requestedBy)
+	})
+}
+
+func checkContractOverlap(q querier, contract *models.Contract) error {
+	if _, err := q.Exec(`SELECT pg_advisory_xact_lock(hashtext($1))`, "chain_contracts:"+contract.Chain); err != nil {
+		return fmt.Errorf("failed to lock chain contracts: %w", err)
+	}
+
+	query := `
+		SELECT id
+		FROM chain_contracts
+		WHERE chain = $1
+			AND id <> $2
+			AND effective_from <= COALESCE($4::date, 'infinity'::date)
+			AND COALESCE(effective_to, 'infinity'::date) >= $3
+		LIMIT 1`
+
+	var existingID int
+	err := q.QueryRow(query, contract.Chain, contract.ID, contract.EffectiveFrom, contract.EffectiveTo).Scan(&existingID)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check contract overlap: %w", err)
+	}
+
+	return fmt.Errorf("%w: contract %d for chain %s", models.ErrContractOverlap, existingID, contract.Chain)
+}
+
+func insertContractChange(q querier, contract *models.Contract, action, requestedBy string) error {
+	query := `
+		INSERT INTO chain_contract_history
+			(contract_id, action, chain, basis, percent, dispensing_fee, effective_from, effective_to, requested_by, timestamp)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err := q.Exec(query,
+		contract.ID,
+		action,
+		contract.Chain,
+		contract.Basis,
+		contract.Percent,
+		contract.DispensingFee,
+		contract.EffectiveFrom,
+		contract.EffectiveTo,
+		nullString(requestedBy),
+		contract.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record contract change: %w", err)
+	}
+
+	return nil
+}
+
+func (pr *Postgres) ListContractHistory(contractID int) ([]models.ContractChange, error) {
+	query := `
+		SELECT id, contract_id, action, chain, basis, percent, dispensing_fee, effective_from, effective_to, requested_by, timestamp
+		FROM chain_contract_history
+		WHERE contract_id = $1
+		ORDER BY timestamp, id`
+
+	rows, err := pr.db.Query(query, contractID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contract history: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []models.ContractChange{}
+	for rows.Next() {
+		var change models.ContractChange
+		var requestedBy sql.NullString
+		err := rows.Scan(
+			&change.ID,
+			&change.ContractID,
+			&change.Action,
+			&change.Chain,
+			&change.Basis,
+			&change.Percent,
+			&change.DispensingFee,
+			&change.EffectiveFrom,
+			&change.EffectiveTo,
+			&requestedBy,
+			&change.Timestamp,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan contract change: %w", err)
+		}
+		change.RequestedBy = requestedBy.String
+		changes = append(changes, change)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate contract history: %w", err)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"pharmacyclaims/internal/models"
+)
+
+func (pr *Postgres) GetDrugPrice(ndc string, serviceDate time.Time) (*models.DrugPrice, error) {
+	query := `
+		SELECT ndc, awp, wac, mac, nadac, effective_date
+		FROM drug_prices
+		WHERE ndc = $1 AND effective_date <= $2
+		ORDER BY effective_date DESC
+		LIMIT 1`
+
+	price := &models.DrugPrice{}
+	err := pr.db.QueryRow(query, ndc, serviceDate).Scan(
+		&price.NDC,
+		&price.AWP,
+		&price.WAC,
+		&price.MAC,
+		&price.NADAC,
+		&price.EffectiveDate,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: ndc %s on %s", models.ErrDrugPriceNotFound, ndc, serviceDate.Format(time.DateOnly))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drug price: %w", err)
+	}
+
+	return price, nil
+}
+
+func (pr *Postgres) BatchCreateDrugPrices(prices []models.DrugPrice) error {
+	columns := []string{"ndc", "awp", "wac", "mac", "nadac", "effective_date"}
+	values := make([][]interface{}, len(prices))
+
+	for i, price := range prices {
+		values[i] = []interface{}{price.NDC, price.AWP, price.WAC, price.MAC, price.NADAC, price.EffectiveDate}
+	}
+
+	return pr.batchInsert("drug_prices", columns, values)
+}
+
+func (pr *Postgres) CountDrugPrices() (int, error) {
+	return pr.countRows("drug_prices")
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"fmt"
+
+	"pharmacyclaims/internal/models"
+
+	"github.com/lib/pq"
+)
+
+func (pr *Postgres) GetDrugClasses(ndcs []string) (map[string]string, error) {
+	query := `
+		SELECT ndc, drug_class
+		FROM drug_classes
+		WHERE ndc = ANY($1)`
+
+	rows, err := pr.db.Query(query, pq.Array(ndcs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get drug classes: %w", err)
+	}
+	defer rows.Close()
+
+	classes := make(map[string]string, len(ndcs))
+	for rows.Next() {
+		var ndc, drugClass string
+		if err := rows.Scan(&ndc, &drugClass); err != nil {
+			return nil, fmt.Errorf("failed to scan drug class: %w", err)
+		}
+		classes[ndc] = drugClass
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate drug classes: %w", err)
+	}
+
+	return classes, nil
+}
+
+func (pr *Postgres) ListDrugInteractions(drugClass string) ([]models.DrugInteraction, error) {
+	query := `
+		SELECT id, class_a, class_b, severity, description
+		FROM drug_interactions
+		WHERE class_a = $1 OR class_b = $1
+		ORDER BY id`
+
+	rows, err := pr.db.Query(query, drugClass)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list drug interactions: %w", err)
+	}
+	defer rows.Close()
+
+	interactions := []models.DrugInteraction{}
+	for rows.Next() {
+		var interaction models.DrugInteraction
+		err := rows.Scan(
+			&interaction.ID,
+			&interaction.ClassA,
+			&interaction.ClassB,
+			&interaction.Severity,
+			&interaction.Description,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan drug interaction: %w", err)
+		}
+		interactions = append(interactions, interaction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate drug interactions: %w", err)
+	}
+
+	return interactions, nil
+}
+
+func (pr *Postgres) BatchCreateDrugClasses(classes []models.DrugClass) error {
+	columns := []string{"ndc", "drug_class"}
+	values := make([][]interface{}, len(classes))
+
+	for i, class := range classes {
+		values[i] = []interface{}{class.NDC, class.DrugClass}
+	}
+
+	return pr.batchInsert("drug_classes", columns, values)
+}
+
+func (pr *Postgres) CountDrugClasses() (int, error) {
+	return pr.countRows("drug_classes")
+}
+
+func (pr *Postgres) BatchCreateDrugInteractions(interactions []models.DrugInteraction) error {
+	columns := []string{"class_a", "class_b", "severity", "description"}
+	values := make([][]interface{}, len(interactions))
+
+	for i, interaction := range interactions {
+		values[i] = []interface{}{interaction.ClassA, interaction.ClassB, interaction.Severity, interaction.Description}
+	}
+
+	return pr.batchInsert("drug_interactions", columns, values)
+}
+
+func (pr *Postgres) CountDrugInteractions() (int, error) {
+	return pr.countRows("drug_interactions")
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"pharmacyclaims/internal/models"
+)
+
+func (pr *Postgres) GetFormularyEntry(planID, ndc string) (*models.FormularyEntry, error) {
+	query := `
+		SELECT id, plan_id, ndc, tier, quantity_limit, prior_auth_required, step_therapy_required
+		FROM formulary_entries
+		WHERE plan_id = $1 AND ndc = $2`
+
+	entry := &models.FormularyEntry{}
+	err := pr.db.QueryRow(query, planID, ndc).Scan(
+		&entry.ID,
+		&entry.PlanID,
+		&entry.NDC,
+		&entry.Tier,
+		&entry.QuantityLimit,
+		&entry.PriorAuthRequired,
+		&entry.StepTherapyRequired,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: ndc %s on plan %s", models.ErrNotOnFormulary, ndc, planID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get formulary entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (pr *Postgres) BatchCreateFormularyEntries(entries []models.FormularyEntry) error {
+	columns := []string{"plan_id", "ndc", "tier", "quantity_limit", "prior_auth_required", "step_therapy_required"}
+	values := make([][]interface{}, len(entries))
+
+	for i, entry := range entries {
+		values[i] = []interface{}{
+			entry.PlanID,
+			entry.NDC,
+			entry.Tier,
+			entry.QuantityLimit,
+			entry.PriorAuthRequired,
+			entry.StepTherapyRequired,
+		}
+	}
+
+	return pr.batchInsert("formulary_entries", columns, values)
+}
+
+func (pr *Postgres) CountFormularyEntries() (int, error) {
+	return pr.countRows("formulary_entries")
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"pharmacyclaims/internal/models"
+)
+
+func (pr *Postgres) GetIdempotencyRecord(key string, now time.Time) (*models.IdempotencyRecord, error) {
+	query := `
+		SELECT key, request_hash, claim_id, response, created_at, expires_at
+		FROM idempotency_keys
+		WHERE key = $1 AND expires_at > $2`
+
+	record := &models.IdempotencyRecord{}
+	var response []byte
+	err := pr.db.QueryRow(query, key, now).Scan(
+		&record.Key,
+		&record.RequestHash,
+		&record.ClaimID,
+		&response,
+		&record.CreatedAt,
+		&record.ExpiresAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", models.ErrIdempotencyKeyNotFound, key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	if err := json.Unmarshal(response, &record.Response); err != nil {
+		return nil, fmt.Errorf("failed to decode stored response: %w", err)
+	}
+
+	return record, nil
+}
+
+func (pr *Postgres) CreateClaimWithIdempotencyKey(claim *models.Claim, record *models.IdempotencyRecord, costShare CostShareFunc) error {
+	return pr.db.ExecuteInTransaction(func(tx *sql.Tx) error {
+		if err := insertClaim(tx, claim, costShare); err != nil {
+			return err
+		}
+
+		if record.Response.CostShare != nil {
+			paid := claim.CostShare
+			record.Response.CostShare = &paid
+		}
+		response, err := json.Marshal(record.Response)
+		if err != nil {
+			return fmt.Errorf("failed to encode response: %w", err)
+		}
+
+		query := `
+			INSERT INTO idempotency_keys (key, request_hash, claim_id, response, created_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (key) DO UPDATE
+			SET request_hash = EXCLUDED.request_hash,
+				claim_id = EXCLUDED.claim_id,
+				response = EXCLUDED.response,
+				created_at = EXCLUDED.created_at,
+				expires_at = EXCLUDED.expires_at
+			WHERE idempotency_keys.expires_at <= EXCLUDED.created_at`
+
+		result, err := tx.Exec(query,
+			record.Key,
+			record.RequestHash,
+			record.ClaimID,
+			response,
+			record.CreatedAt,
+			record.ExpiresAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to store idempotency key: %w", err)
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to store idempotency key: %w", err)
+		}
+		if affected == 0 {
+			return fmt.Errorf("%w: %s", models.ErrIdempotencyKeyInUse, record.Key)
+		}
+
+		return nil
+	})
+}
+
+func (pr *Postgres) DeleteExpiredIdempotencyKeys(now time.Time) (int64, error) {
+	result, err := pr.db.Exec(`DELETE FROM idempotency_keys WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count deleted idempotency keys: %w", err)
+	}
+
+	return deleted, nil
+}
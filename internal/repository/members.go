@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"pharmacyclaims/internal/models"
+)
+
+func (pr *Postgres) GetMember(memberID string) (*models.Member, error) {
+	query := `
+		SELECT id, member_id, group_id, plan_id, effective_date, termination_date
+		FROM members
+		WHERE member_id = $1`
+
+	member := &models.Member{}
+	err := pr.db.QueryRow(query, memberID).Scan(
+		&member.ID,
+		&member.MemberID,
+		&member.GroupID,
+		&member.PlanID,
+		&member.EffectiveDate,
+		&member.TerminationDate,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: member_id %s", models.ErrMemberNotFound, memberID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+
+	return member, nil
+}
+
+func (pr *Postgres) BatchCreateMembers(members []models.Member) error {
+	columns := []string{"member_id", "group_id", "plan_id", "effective_date", "termination_date"}
+	values := make([][]interface{}, len(members))
+
+	for i, member := range members {
+		values[i] = []interface{}{member.MemberID, member.GroupID, member.PlanID, member.EffectiveDate, member.TerminationDate}
+	}
+
+	return pr.batchInsert("members", columns, values)
+}
+
+func (pr *Postgres) CountMembers() (int, error) {
+	return pr.countRows("members")
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"pharmacyclaims/internal/models"
+
+	"github.com/lib/pq"
+)
+
+func (pr *Postgres) GetNPIRecord(npi string) (*models.NPIRecord, error) {
+	query := `
+		SELECT npi, entity_type, name, taxonomy_codes, deactivation_date
+		FROM npi_registry
+		WHERE npi = $1`
+
+	record := &models.NPIRecord{}
+	err := pr.db.QueryRow(query, npi).Scan(
+		&record.NPI,
+		&record.EntityType,
+		&record.Name,
+		pq.Array(&record.TaxonomyCodes),
+		&record.DeactivationDate,
+	)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: npi %s", models.ErrNPINotRegistered, npi)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get NPI record: %w", err)
+	}
+
+	return record, nil
+}
+
+func (pr *Postgres) BatchUpsertNPIRecords(records []models.NPIRecord) error {
+	tx, err := pr.db.BeginTx()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO npi_registry (npi, entity_type, name, taxonomy_codes, deactivation_date)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (npi) DO UPDATE
+		SET entity_type = EXCLUDED.entity_type,
+			name = EXCLUDED.name,
+			taxonomy_codes = EXCLUDED.taxonomy_codes,
+			deactivation_date = EXCLUDED.deactivation_date,
+			updated_at = NOW()`)
+	if err != nil {
+		return fmt.Errorf("failed to prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, record := range records {
+		codes := record.TaxonomyCodes
+		if codes == nil {
+			codes = []string{}
+		}
+		if _, err := stmt.Exec(record.NPI, record.EntityType, record.Name, pq.Array(codes), record.DeactivationDate); err != nil {
+			return fmt.Errorf("failed to upsert NPI record %s: %w", record.NPI, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (pr *Postgres) CountNPIRecords() (int, error) {
+	return pr.countRows("npi_registry")
+}
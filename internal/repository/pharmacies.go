@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"pharmacyclaims/internal/models"
+
+	"github.com/lib/pq"
+)
+
+func (pr *Postgres) ListPharmacies(filter models.PharmacyFilter) ([]models.Pharmacy, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Chain != "" {
+		args = append(args, filter.Chain)
+		conditions = append(conditions, fmt.Sprintf("chain = $%d", len(args)))
+	}
+	if filter.Active != nil {
+		args = append(args, *filter.Active)
+		conditions = append(conditions, fmt.Sprintf("active = $%d", len(args)))
+	}
+
+	query := `
+		SELECT id, npi, chain, active
+		FROM pharmacies`
+	if len(conditions) > 0 {
+		query += "\n\t\tWHERE " + strings.Join(conditions, " AND ")
+	}
+	query += "\n\t\tORDER BY npi"
+
+	rows, err := pr.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pharmacies: %w", err)
+	}
+	defer rows.Close()
+
+	pharmacies := []models.Pharmacy{}
+	for rows.Next() {
+		var pharmacy models.Pharmacy
+		if err := rows.Scan(&pharmacy.ID, &pharmacy.NPI, &pharmacy.Chain, &pharmacy.Active); err != nil {
+			return nil, fmt.Errorf("failed to scan pharmacy: %w", err)
+		}
+		pharmacies = append(pharmacies, pharmacy)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate pharmacies: %w", err)
+	}
+
+	return pharmacies, nil
+}
+
+func (pr *Postgres) CreatePharmacy(pharmacy *models.Pharmacy) error {
+	query := `
+		INSERT INTO pharmacies (npi, chain, active)
+		VALUES ($1, $2, $3)
+		RETURNING id`
+
+	err := pr.db.QueryRow(query, pharmacy.NPI, pharmacy.Chain, pharmacy.Active).Scan(&pharmacy.ID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return fmt.Errorf("%w: npi %s", models.ErrPharmacyExists, pharmacy.NPI)
+		}
+		return fmt.Errorf("failed to create pharmacy: %w", err)
+	}
+
+	return nil
+}
+
+func (pr *Postgres) UpdatePharmacy(pharmacy *models.Pharmacy) error {
+	query := `
+		UPDATE pharmacies
+		SET chain = $2, active = $3
+		WHERE npi = $1
+		RETURNING id`
+
+	err := pr.db.QueryRow(query, pharmacy.NPI, pharmacy.Chain, pharmacy.Active).Scan(&pharmacy.ID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: npi %s", models.ErrPharmacyNotFound, pharmacy.NPI)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update pharmacy: %w", err)
+	}
+
+	return nil
+}
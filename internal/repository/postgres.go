@@ -2,6 +2,7 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -22,7 +23,7 @@ func NewPostgresRepository(db *database.DB) *Postgres {
 
 func (pr *Postgres) GetPharmacyByNPI(npi string) (*models.Pharmacy, error) {
 	query := `
-		SELECT id, npi, chain
+		SELECT id, npi, chain, active
 		FROM pharmacies
 		WHERE npi = $1`
 
@@ -31,10 +32,11 @@ func (pr *Postgres) GetPharmacyByNPI(npi string) (*models.Pharmacy, error) {
 		&pharmacy.ID,
 		&pharmacy.NPI,
 		&pharmacy.Chain,
+		&pharmacy.Active,
 	)
 
 	if err == sql.ErrNoRows {
-		return nil, nil
+		return nil, fmt.Errorf("%w: npi %s", models.ErrPharmacyNotFound, npi)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get pharmacy by NPI: %w", err)
@@ -43,91 +45,260 @@ func (pr *Postgres) GetPharmacyByNPI(npi string) (*models.Pharmacy, error) {
 	return pharmacy, nil
 }
 
-func (pr *Postgres) CreateClaim(claim *models.Claim) error {
-	query := `
-		INSERT INTO claims (id, ndc, quantity, npi, price, timestamp)
-		VALUES ($1, $2, $3, $4, $5, $6)`
+type querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+func (pr *Postgres) CreateClaim(claim *models.Claim, costShare CostShareFunc) error {
+	return pr.db.ExecuteInTransaction(func(tx *sql.Tx) error {
+		return insertClaim(tx, claim, costShare)
+	})
+}
+
+const claimColumns = `c.id, c.ndc, c.quantity, c.npi, c.price, c.timestamp, c.member_id, c.service_date, c.status, c.rejects, c.duplicate_of, c.original_claim_id,
+	c.pricing_basis, c.ingredient_cost, c.dispensing_fee, c.allowed_amount, c.contract_id,
+	c.deductible_amount, c.copay_amount, c.coinsurance_amount, c.patient_pay_amount, c.plan_paid_amount, c.days_supply, c.dur,
+	c.daw_code, c.fill_number, c.prescription_number, c.date_written, c.prescriber_npi, c.prior_auth_id`
+
+func claimFields(claim *models.Claim) []interface{} {
+	return []interface{}{
+		&claim.ID,
+		&claim.NDC,
+		&claim.Quantity,
+		&claim.NPI,
+		&claim.Price,
+		&claim.Timestamp.Time,
+		&claim.MemberID,
+		&claim.ServiceDate,
+		&claim.Status,
+		jsonColumn{&claim.Rejects},
+		&claim.DuplicateOf,
+		&claim.OriginalClaimID,
+		&claim.Pricing.Basis,
+		&claim.Pricing.IngredientCost,
+		&claim.Pricing.DispensingFee,
+		&claim.Pricing.Total,
+		&claim.Pricing.ContractID,
+		&claim.CostShare.Deductible,
+		&claim.CostShare.Copay,
+		&claim.CostShare.Coinsurance,
+		&claim.CostShare.PatientPay,
+		&claim.CostShare.PlanPaid,
+		&claim.DaysSupply,
+		jsonColumn{&claim.DUR},
+		&claim.DAWCode,
+		&claim.FillNumber,
+		&claim.PrescriptionNumber,
+		&claim.DateWritten,
+		&claim.PrescriberNPI,
+		&claim.PriorAuthID,
+	}
+}
+
+type jsonColumn struct {
+	target interface{}
+}
+
+func (c jsonColumn) Scan(src interface{}) error {
+	if src == nil {
+		return nil
+	}
+
+	data, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupported JSON column type %T", src)
+	}
 
-	_, err := pr.db.Exec(query,
+	return json.Unmarshal(data, c.target)
+}
+
+func insertClaim(q querier, claim *models.Claim, costShare CostShareFunc) error {
+	if claim.Status == models.ClaimStatusPaid && costShare != nil {
+		if err := applyCostShare(q, claim, costShare); err != nil {
+			return err
+		}
+	}
+
+	var rejects []byte
+	if len(claim.Rejects) > 0 {
+		var err error
+		if rejects, err = json.Marshal(claim.Rejects); err != nil {
+			return fmt.Errorf("failed to encode claim rejects: %w", err)
+		}
+	}
+
+	var dur []byte
+	if len(claim.DUR) > 0 {
+		var err error
+		if dur, err = json.Marshal(claim.DUR); err != nil {
+			return fmt.Errorf("failed to encode claim DUR alerts: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO claims (id, ndc, quantity, npi, price, timestamp, member_id, service_date, status, rejects,
+			duplicate_of, original_claim_id, pricing_basis, ingredient_cost, dispensing_fee, allowed_amount, contract_id,
+			deductible_amount, copay_amount, coinsurance_amount, patient_pay_amount, plan_paid_amount, days_supply, dur,
+			daw_code, fill_number, prescription_number, date_written, prescriber_npi, prior_auth_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24,
+			$25, $26, $27, $28, $29, $30)`
+
+	_, err := q.Exec(query,
 		claim.ID,
 		claim.NDC,
 		claim.Quantity,
 		claim.NPI,
 		claim.Price,
 		claim.Timestamp.Time,
+		claim.MemberID,
+		claim.ServiceDate,
+		claim.Status,
+		rejects,
+		claim.DuplicateOf,
+		claim.OriginalClaimID,
+		claim.Pricing.Basis,
+		claim.Pricing.IngredientCost,
+		claim.Pricing.DispensingFee,
+		claim.Pricing.Total,
+		claim.Pricing.ContractID,
+		claim.CostShare.Deductible,
+		claim.CostShare.Copay,
+		claim.CostShare.Coinsurance,
+		claim.CostShare.PatientPay,
+		claim.CostShare.PlanPaid,
+		claim.DaysSupply,
+		dur,
+		claim.DAWCode,
+		claim.FillNumber,
+		claim.PrescriptionNumber,
+		claim.DateWritten,
+		claim.PrescriberNPI,
+		claim.PriorAuthID,
 	)
 
 	if err != nil {
 		return fmt.Errorf("failed to create claim: %w", err)
 	}
 
-	return nil
+	if claim.Status != models.ClaimStatusPaid {
+		return nil
+	}
+	if err := consumePriorAuth(q, claim); err != nil {
+		return err
+	}
+	return insertAccumulatorEntry(q, &models.AccumulatorEntry{
+		MemberID:   claim.MemberID,
+		PlanYear:   claim.ServiceDate.Year(),
+		ClaimID:    &claim.ID,
+		EntryType:  models.AccumulatorEntryClaim,
+		Deductible: claim.CostShare.Deductible,
+		OOP:        claim.CostShare.PatientPay,
+		PostedAt:   claim.Timestamp.Time,
+	})
 }
 
 func (pr *Postgres) GetClaimByID(id uuid.UUID) (*models.Claim, error) {
 	query := `
-		SELECT id, ndc, quantity, npi, price, timestamp
-		FROM claims
-		WHERE id = $1`
+		SELECT ` + claimColumns + `
+		FROM claims c
+		WHERE c.id = $1`
 
 	claim := &models.Claim{}
-	var timestamp time.Time
-	err := pr.db.QueryRow(query, id).Scan(
-		&claim.ID,
-		&claim.NDC,
-		&claim.Quantity,
-		&claim.NPI,
-		&claim.Price,
-		&timestamp,
-	)
+	err := pr.db.QueryRow(query, id).Scan(claimFields(claim)...)
 
 	if err == sql.ErrNoRows {
-		return nil, nil
+		return nil, fmt.Errorf("%w: id %s", models.ErrClaimNotFound, id)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get claim by ID: %w", err)
 	}
 
-	claim.Timestamp = models.CustomTime{Time: timestamp}
 	return claim, nil
 }
 
-func (pr *Postgres) ReverseClaim(claimID uuid.UUID, reason string) error {
+func (pr *Postgres) ReverseClaim(reversal *models.Reversal) error {
 	return pr.db.ExecuteInTransaction(func(tx *sql.Tx) error {
-		var exists bool
-		checkQuery := `SELECT EXISTS(SELECT 1 FROM claims WHERE id = $1)`
-		err := tx.QueryRow(checkQuery, claimID).Scan(&exists)
-		if err != nil {
-			return fmt.Errorf("failed to check if claim exists: %w", err)
-		}
+		return reverseClaim(tx, reversal)
+	})
+}
 
-		if !exists {
-			return fmt.Errorf("claim not found")
+func (pr *Postgres) RebillClaim(reversal *models.Reversal, claim *models.Claim, costShare CostShareFunc) error {
+	return pr.db.ExecuteInTransaction(func(tx *sql.Tx) error {
+		if err := reverseClaim(tx, reversal); err != nil {
+			return err
 		}
+		return insertClaim(tx, claim, costShare)
+	})
+}
 
-		var reversalExists bool
-		reversalCheckQuery := `SELECT EXISTS(SELECT 1 FROM reversals WHERE claim_id = $1)`
-		err = tx.QueryRow(reversalCheckQuery, claimID).Scan(&reversalExists)
-		if err != nil {
-			return fmt.Errorf("failed to check if claim already reversed: %w", err)
-		}
+func reverseClaim(q querier, reversal *models.Reversal) error {
+	var status, memberID string
+	var serviceDate time.Time
+	var deductible, patientPay float64
+	checkQuery := `
+		SELECT status, member_id, service_date, deductible_amount, patient_pay_amount
+		FROM claims
+		WHERE id = $1
+		FOR UPDATE`
+	err := q.QueryRow(checkQuery, reversal.ClaimID).Scan(&status, &memberID, &serviceDate, &deductible, &patientPay)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: id %s", models.ErrClaimNotFound, reversal.ClaimID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check if claim exists: %w", err)
+	}
 
-		if reversalExists {
-			return fmt.Errorf("claim already reversed")
-		}
+	if status != models.ClaimStatusPaid {
+		return fmt.Errorf("%w: id %s cannot be reversed", models.ErrClaimRejected, reversal.ClaimID)
+	}
 
-		insertReversalQuery := `
-			INSERT INTO reversals (id, claim_id, timestamp)
-			VALUES ($1, $2, $3)`
+	var reversalExists bool
+	reversalCheckQuery := `SELECT EXISTS(SELECT 1 FROM reversals WHERE claim_id = $1)`
+	err = q.QueryRow(reversalCheckQuery, reversal.ClaimID).Scan(&reversalExists)
+	if err != nil {
+		return fmt.Errorf("failed to check if claim already reversed: %w", err)
+	}
 
-		reversalID := uuid.New()
-		now := time.Now()
-		_, err = tx.Exec(insertReversalQuery, reversalID, claimID, now)
-		if err != nil {
-			return fmt.Errorf("failed to create reversal record: %w", err)
-		}
+	if reversalExists {
+		return fmt.Errorf("%w: id %s", models.ErrAlreadyReversed, reversal.ClaimID)
+	}
 
-		return nil
+	insertReversalQuery := `
+		INSERT INTO reversals (id, claim_id, timestamp, reason, reason_code, requested_by, source, window_override)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+	_, err = q.Exec(insertReversalQuery,
+		reversal.ID,
+		reversal.ClaimID,
+		reversal.Timestamp.Time,
+		nullString(reversal.Reason),
+		nullString(reversal.ReasonCode),
+		nullString(reversal.RequestedBy),
+		nullString(reversal.Source),
+		reversal.WindowOverride,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create reversal record: %w", err)
+	}
+
+	if err := restorePriorAuth(q, reversal.ClaimID); err != nil {
+		return err
+	}
+
+	if err := lockAccumulator(q, memberID, serviceDate.Year()); err != nil {
+		return err
+	}
+
+	return insertAccumulatorEntry(q, &models.AccumulatorEntry{
+		MemberID:   memberID,
+		PlanYear:   serviceDate.Year(),
+		ClaimID:    &reversal.ClaimID,
+		EntryType:  models.AccumulatorEntryReversal,
+		Deductible: -deductible,
+		OOP:        -patientPay,
+		PostedAt:   reversal.Timestamp.Time,
 	})
 }
 
@@ -143,22 +314,55 @@ func (pr *Postgres) BatchCreatePharmacies(pharmacies []models.Pharmacy) error {
 }
 
 func (pr *Postgres) BatchCreateClaims(claims []models.Claim) error {
-	columns := []string{"id", "ndc", "quantity", "npi", "price", "timestamp"}
+	columns := []string{
+		"id", "ndc", "quantity", "npi", "price", "timestamp", "member_id", "service_date",
+		"pricing_basis", "ingredient_cost", "dispensing_fee", "allowed_amount", "plan_paid_amount", "days_supply",
+		"daw_code", "fill_number", "prescription_number", "date_written", "prescriber_npi",
+	}
 	values := make([][]interface{}, len(claims))
 
 	for i, claim := range claims {
-		values[i] = []interface{}{claim.ID, claim.NDC, claim.Quantity, claim.NPI, claim.Price, claim.Timestamp.Time}
+		values[i] = []interface{}{
+			claim.ID,
+			claim.NDC,
+			claim.Quantity,
+			claim.NPI,
+			claim.Price,
+			claim.Timestamp.Time,
+			claim.MemberID,
+			claim.ServiceDate,
+			claim.Pricing.Basis,
+			claim.Pricing.IngredientCost,
+			claim.Pricing.DispensingFee,
+			claim.Pricing.Total,
+			claim.CostShare.PlanPaid,
+			claim.DaysSupply,
+			claim.DAWCode,
+			claim.FillNumber,
+			claim.PrescriptionNumber,
+			claim.DateWritten,
+			claim.PrescriberNPI,
+		}
 	}
 
 	return pr.batchInsert("claims", columns, values)
 }
 
 func (pr *Postgres) BatchCreateReversals(reversals []models.Reversal) error {
-	columns := []string{"id", "claim_id", "timestamp"}
+	columns := []string{"id", "claim_id", "timestamp", "reason", "reason_code", "requested_by", "source", "window_override"}
 	values := make([][]interface{}, len(reversals))
 
 	for i, reversal := range reversals {
-		values[i] = []interface{}{reversal.ID, reversal.ClaimID, reversal.Timestamp.Time}
+		values[i] = []interface{}{
+			reversal.ID,
+			reversal.ClaimID,
+			reversal.Timestamp.Time,
+			nullString(reversal.Reason),
+			nullString(reversal.ReasonCode),
+			nullString(reversal.RequestedBy),
+			nullString(reversal.Source),
+			reversal.WindowOverride,
+		}
 	}
 
 	return pr.batchInsert("reversals", columns, values)
@@ -211,6 +415,10 @@ func (pr *Postgres) CountReversals() (int, error) {
 	return pr.countRows("reversals")
 }
 
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
 func (pr *Postgres) countRows(tableName string) (int, error) {
 	var count int
 	query := fmt.Sprintf("SELECT COUNT(*) FROM %s", tableName)
@@ -220,3 +428,269 @@ func (pr *Postgres) countRows(tableName string) (int, error) {
 	}
 	return count, nil
 }
+
+const claimDetailsSelect = `
+	SELECT ` + claimColumns + `,
+		r.timestamp, r.reason, r.reason_code, r.requested_by, r.window_override
+	FROM claims c
+	LEFT JOIN LATERAL (
+		SELECT timestamp, reason, reason_code, requested_by, window_override
+		FROM reversals
+		WHERE claim_id = c.id
+		ORDER BY timestamp
+		LIMIT 1
+	) r ON true`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanClaimDetails(row rowScanner) (*models.ClaimDetails, error) {
+	details := &models.ClaimDetails{}
+	var reversedAt sql.NullTime
+	var reason, reasonCode, requestedBy sql.NullString
+	var windowOverride sql.NullBool
+	fields := append(claimFields(&details.Claim), &reversedAt, &reason, &reasonCode, &requestedBy, &windowOverride)
+	if err := row.Scan(fields...); err != nil {
+		return nil, err
+	}
+
+	if reversedAt.Valid {
+		details.Reversed = true
+		details.ReversedAt = &reversedAt.Time
+		details.ReversalReason = reason.String
+		details.ReversalReasonCode = reasonCode.String
+		details.ReversedBy = requestedBy.String
+		details.ReversalWindowOverride = windowOverride.Bool
+	}
+	return details, nil
+}
+
+func (pr *Postgres) GetClaimDetailsByID(id uuid.UUID) (*models.ClaimDetails, error) {
+	query := claimDetailsSelect + `
+	WHERE c.id = $1`
+
+	details, err := scanClaimDetails(pr.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: id %s", models.ErrClaimNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claim details by ID: %w", err)
+	}
+
+	return details, nil
+}
+
+func (pr *Postgres) SearchClaims(filter models.ClaimSearchFilter) ([]models.ClaimDetails, error) {
+	var conditions []string
+	var args []interface{}
+
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if filter.NPI != "" {
+		addCondition("c.npi = $%d", filter.NPI)
+	}
+	if filter.NDC != "" {
+		addCondition("c.ndc = $%d", filter.NDC)
+	}
+	if filter.DaysSupply != nil {
+		addCondition("c.days_supply = $%d", *filter.DaysSupply)
+	}
+	if filter.DAWCode != "" {
+		addCondition("c.daw_code = $%d", filter.DAWCode)
+	}
+	if filter.FillNumber != nil {
+		addCondition("c.fill_number = $%d", *filter.FillNumber)
+	}
+	if filter.PrescriptionNumber != "" {
+		addCondition("c.prescription_number = $%d", filter.PrescriptionNumber)
+	}
+	if filter.DateWritten != nil {
+		addCondition("c.date_written = $%d", *filter.DateWritten)
+	}
+	if filter.PrescriberNPI != "" {
+		addCondition("c.prescriber_npi = $%d", filter.PrescriberNPI)
+	}
+	if filter.From != nil {
+		addCondition("c.timestamp >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("c.timestamp < $%d", *filter.To)
+	}
+	if filter.Status != "" {
+		addCondition("c.status = $%d", filter.Status)
+	}
+	if filter.Reversed != nil {
+		if *filter.Reversed {
+			conditions = append(conditions, "r.timestamp IS NOT NULL")
+		} else {
+			conditions = append(conditions, "r.timestamp IS NULL")
+		}
+	}
+	if filter.After != nil {
+		args = append(args, filter.After.Timestamp, filter.After.ID)
+		conditions = append(conditions, fmt.Sprintf("(c.timestamp, c.id) < ($%d, $%d)", len(args)-1, len(args)))
+	}
+
+	query := claimDetailsSelect
+	if len(conditions) > 0 {
+		query += "\n\tWHERE " + strings.Join(conditions, " AND ")
+	}
+
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf("\n\tORDER BY c.timestamp DESC, c.id DESC\n\tLIMIT $%d", len(args))
+
+	rows, err := pr.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search claims: %w", err)
+	}
+	defer rows.Close()
+
+	claims := []models.ClaimDetails{}
+	for rows.Next() {
+		details, err := scanClaimDetails(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan claim: %w", err)
+		}
+		claims = append(claims, *details)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate claims: %w", err)
+	}
+
+	return claims, nil
+}
+
+func (pr *Postgres) FindDuplicateClaim(claim *models.Claim, window time.Duration) (*models.Claim, error) {
+	args := []interface{}{
+		claim.NPI,
+		claim.NDC,
+		claim.Quantity,
+		claim.Timestamp.Add(-window),
+		claim.Timestamp.Add(window),
+		claim.MemberID,
+	}
+
+	exclude := ""
+	if claim.OriginalClaimID != nil {
+		args = append(args, *claim.OriginalClaimID)
+		exclude = fmt.Sprintf("AND c.id <> $%d", len(args))
+	}
+
+	query := `
+		SELECT ` + claimColumns + `
+		FROM claims c
+		WHERE c.npi = $1
+			AND c.ndc = $2
+			AND c.quantity = $3
+			AND c.timestamp BETWEEN $4 AND $5
+			AND c.member_id = $6
+			AND c.status = 'paid'
+			AND NOT EXISTS (SELECT 1 FROM reversals r WHERE r.claim_id = c.id)
+			` + exclude + `
+		ORDER BY c.timestamp DESC
+		LIMIT 1`
+
+	duplicate := &models.Claim{}
+	err := pr.db.QueryRow(query, args...).Scan(claimFields(duplicate)...)
+
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: no duplicate of claim %s", models.ErrClaimNotFound, claim.ID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate claim: %w", err)
+	}
+
+	return duplicate, nil
+}
+
+func (pr *Postgres) FindClaimForReversal(match models.ReversalMatch) (*models.Claim, error) {
+	query := `
+		SELECT ` + claimColumns + `
+		FROM claims c
+		WHERE c.npi = $1
+			AND c.ndc = $2
+			AND c.service_date = $3
+			AND c.member_id = $4
+			AND c.prescription_number = $5
+			AND c.fill_number = $6
+			AND c.status = 'paid'
+			AND NOT EXISTS (SELECT 1 FROM reversals r WHERE r.claim_id = c.id)
+		LIMIT 2`
+
+	rows, err := pr.db.Query(query, match.NPI, match.NDC, match.ServiceDate, match.MemberID, match.PrescriptionNumber, match.FillNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find claim for reversal: %w", err)
+	}
+	defer rows.Close()
+
+	var claims []*models.Claim
+	for rows.Next() {
+		claim := &models.Claim{}
+		if err := rows.Scan(claimFields(claim)...); err != nil {
+			return nil, fmt.Errorf("failed to scan claim for reversal: %w", err)
+		}
+		claims = append(claims, claim)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate claims for reversal: %w", err)
+	}
+
+	description := fmt.Sprintf("npi %s ndc %s member %s rx %s fill %d on %s",
+		match.NPI, match.NDC, match.MemberID, match.PrescriptionNumber, match.FillNumber, match.ServiceDate.Format("2006-01-02"))
+	switch len(claims) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", models.ErrClaimNotFound, description)
+	case 1:
+		return claims[0], nil
+	default:
+		return nil, fmt.Errorf("%w: %s", models.ErrClaimAmbiguous, description)
+	}
+}
+
+func (pr *Postgres) FindMemberClaimHistory(claim *models.Claim, since time.Time) ([]models.Claim, error) {
+	args := []interface{}{claim.MemberID, since, claim.ServiceDate, claim.ID}
+
+	exclude := ""
+	if claim.OriginalClaimID != nil {
+		args = append(args, *claim.OriginalClaimID)
+		exclude = fmt.Sprintf("AND c.id <> $%d", len(args))
+	}
+
+	query := `
+		SELECT ` + claimColumns + `
+		FROM claims c
+		WHERE c.member_id = $1
+			AND c.service_date BETWEEN $2 AND $3
+			AND c.id <> $4
+			AND c.status = 'paid'
+			AND NOT EXISTS (SELECT 1 FROM reversals r WHERE r.claim_id = c.id)
+			` + exclude + `
+		ORDER BY c.service_date DESC, c.timestamp DESC`
+
+	rows, err := pr.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find member claim history: %w", err)
+	}
+	defer rows.Close()
+
+	claims := []models.Claim{}
+	for rows.Next() {
+		var history models.Claim
+		if err := rows.Scan(claimFields(&history)...); err != nil {
+			return nil, fmt.Errorf("failed to scan claim history: %w", err)
+		}
+		claims = append(claims, history)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate claim history: %w", err)
+	}
+
+	return claims, nil
+}
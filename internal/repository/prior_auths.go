@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"pharmacyclaims/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const priorAuthColumns = `id, member_id, ndc, drug_class, approved_quantity, used_quantity, approved_quantity - used_quantity,
+	effective_from, effective_to, status, created_at`
+
+func priorAuthFields(priorAuth *models.PriorAuth) []interface{} {
+	return []interface{}{
+		&priorAuth.ID,
+		&priorAuth.MemberID,
+		&priorAuth.NDC,
+		&priorAuth.DrugClass,
+		&priorAuth.ApprovedQuantity,
+		&priorAuth.UsedQuantity,
+		&priorAuth.RemainingQuantity,
+		&priorAuth.EffectiveFrom,
+		&priorAuth.EffectiveTo,
+		&priorAuth.Status,
+		&priorAuth.CreatedAt,
+	}
+}
+
+func (pr *Postgres) CreatePriorAuth(priorAuth *models.PriorAuth) error {
+	query := `
+		INSERT INTO prior_auths (member_id, ndc, drug_class, approved_quantity, effective_from, effective_to, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ` + priorAuthColumns
+
+	err := pr.db.QueryRow(query,
+		priorAuth.MemberID,
+		priorAuth.NDC,
+		priorAuth.DrugClass,
+		priorAuth.ApprovedQuantity,
+		priorAuth.EffectiveFrom,
+		priorAuth.EffectiveTo,
+		priorAuth.Status,
+	).Scan(priorAuthFields(priorAuth)...)
+	if err != nil {
+		return fmt.Errorf("failed to create prior authorization: %w", err)
+	}
+
+	return nil
+}
+
+func (pr *Postgres) GetPriorAuth(id int) (*models.PriorAuth, error) {
+	query := `
+		SELECT ` + priorAuthColumns + `
+		FROM prior_auths
+		WHERE id = $1`
+
+	priorAuth := &models.PriorAuth{}
+	err := pr.db.QueryRow(query, id).Scan(priorAuthFields(priorAuth)...)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: id %d", models.ErrPriorAuthNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prior authorization: %w", err)
+	}
+
+	return priorAuth, nil
+}
+
+func (pr *Postgres) FindPriorAuth(claim *models.Claim, drugClass string) (*models.PriorAuth, error) {
+	query := `
+		SELECT ` + priorAuthColumns + `
+		FROM prior_auths pa
+		WHERE member_id = $1
+			AND status = $2
+			AND $3 BETWEEN effective_from AND effective_to
+			AND (ndc = $4 OR (drug_class <> '' AND drug_class = $5))
+			AND approved_quantity - used_quantity + COALESCE((
+				SELECT c.quantity FROM claims c WHERE c.id = $6 AND c.prior_auth_id = pa.id
+			), 0) >= $7
+		ORDER BY ndc = '', effective_to, id
+		LIMIT 1`
+
+	priorAuth := &models.PriorAuth{}
+	err := pr.db.QueryRow(query,
+		claim.MemberID,
+		models.PriorAuthStatusApproved,
+		claim.ServiceDate,
+		claim.NDC,
+		drugClass,
+		claim.OriginalClaimID,
+		claim.Quantity,
+	).Scan(priorAuthFields(priorAuth)...)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: member %s ndc %s", models.ErrPriorAuthNotFound, claim.MemberID, claim.NDC)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find prior authorization: %w", err)
+	}
+
+	return priorAuth, nil
+}
+
+func consumePriorAuth(q querier, claim *models.Claim) error {
+	if claim.PriorAuthID == nil {
+		return nil
+	}
+
+	query := `
+		UPDATE prior_auths
+		SET used_quantity = used_quantity + $2
+		WHERE id = $1 AND used_quantity + $2 <= approved_quantity`
+
+	result, err := q.Exec(query, *claim.PriorAuthID, claim.Quantity)
+	if err != nil {
+		return fmt.Errorf("failed to consume prior authorization: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to consume prior authorization: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: id %d has less than %g remaining", models.ErrPriorAuthUsed, *claim.PriorAuthID, claim.Quantity)
+	}
+
+	return nil
+}
+
+func restorePriorAuth(q querier, claimID uuid.UUID) error {
+	query := `
+		UPDATE prior_auths pa
+		SET used_quantity = GREATEST(pa.used_quantity - c.quantity, 0)
+		FROM claims c
+		WHERE c.id = $1 AND c.prior_auth_id = pa.id`
+
+	if _, err := q.Exec(query, claimID); err != nil {
+		return fmt.Errorf("failed to restore prior authorization: %w", err)
+	}
+
+	return nil
+}
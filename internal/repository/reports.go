@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"pharmacyclaims/internal/models"
+
+	"github.com/lib/pq"
+)
+
+func (pr *Postgres) GetClaimMetrics(filter models.MetricsFilter) ([]models.ClaimMetrics, error) {
+	conditions := []string{"c.status = 'paid'"}
+	var args []interface{}
+
+	if filter.NPI != "" {
+		args = append(args, filter.NPI)
+		conditions = append(conditions, fmt.Sprintf("c.npi = $%d", len(args)))
+	}
+	if filter.NDC != "" {
+		args = append(args, filter.NDC)
+		conditions = append(conditions, fmt.Sprintf("c.ndc = $%d", len(args)))
+	}
+
+	where := "WHERE " + strings.Join(conditions, " AND ")
+
+	query := fmt.Sprintf(`
+		SELECT c.npi, c.ndc,
+			COUNT(c.id) AS fills,
+			COUNT(r.claim_id) AS reverted,
+			COALESCE(ROUND(SUM(c.price) FILTER (WHERE r.claim_id IS NULL) /
+				NULLIF(SUM(c.quantity) FILTER (WHERE r.claim_id IS NULL), 0), 2), 0) AS avg_price,
+			COALESCE(SUM(c.price) FILTER (WHERE r.claim_id IS NULL), 0) AS total_price
+		FROM claims c
+		LEFT JOIN (SELECT DISTINCT claim_id FROM reversals) r ON r.claim_id = c.id
+		%s
+		GROUP BY c.npi, c.ndc
+		ORDER BY c.npi, c.ndc`, where)
+
+	rows, err := pr.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query claim metrics: %w", err)
+	}
+	defer rows.Close()
+
+	metrics := []models.ClaimMetrics{}
+	for rows.Next() {
+		var m models.ClaimMetrics
+		if err := rows.Scan(&m.NPI, &m.NDC, &m.Fills, &m.Reverted, &m.AvgPrice, &m.TotalPrice); err != nil {
+			return nil, fmt.Errorf("failed to scan claim metrics: %w", err)
+		}
+		metrics = append(metrics, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate claim metrics: %w", err)
+	}
+
+	return metrics, nil
+}
+
+func (pr *Postgres) GetChainRecommendations(filter models.RecommendationFilter) ([]models.ChainRecommendation, error) {
+	args := []interface{}{filter.Limit}
+	ndcCondition := ""
+	if len(filter.NDCs) > 0 {
+		args = append(args, pq.Array(filter.NDCs))
+		ndcCondition = "AND c.ndc = ANY($2)"
+	}
+
+	query := fmt.Sprintf(`
+		WITH chain_prices AS (
+			SELECT c.ndc, p.chain, ROUND(AVG(c.price / NULLIF(c.quantity, 0)), 2) AS avg_price
+			FROM claims c
+			JOIN pharmacies p ON p.npi = c.npi
+			WHERE c.status = 'paid'
+				AND NOT EXISTS (SELECT 1 FROM reversals r WHERE r.claim_id = c.id)
+			%s
+			GROUP BY c.ndc, p.chain
+		), ranked AS (
+			SELECT ndc, chain, avg_price,
+				ROW_NUMBER() OVER (PARTITION BY ndc ORDER BY avg_price, chain) AS rank
+			FROM chain_prices
+			WHERE avg_price IS NOT NULL
+		)
+		SELECT ndc, chain, avg_price
+		FROM ranked
+		WHERE rank <= $1
+		ORDER BY ndc, rank`, ndcCondition)
+
+	rows, err := pr.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query chain recommendations: %w", err)
+	}
+	defer rows.Close()
+
+	recommendations := []models.ChainRecommendation{}
+	for rows.Next() {
+		var ndc string
+		var chain models.ChainPrice
+		if err := rows.Scan(&ndc, &chain.Name, &chain.AvgPrice); err != nil {
+			return nil, fmt.Errorf("failed to scan chain recommendation: %w", err)
+		}
+
+		last := len(recommendations) - 1
+		if last < 0 || recommendations[last].NDC != ndc {
+			recommendations = append(recommendations, models.ChainRecommendation{NDC: ndc})
+			last++
+		}
+		recommendations[last].Chains = append(recommendations[last].Chains, chain)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate chain recommendations: %w", err)
+	}
+
+	return recommendations, nil
+}
+
+func (pr *Postgres) GetMostPrescribedQuantities(filter models.QuantityFilter) ([]models.QuantityReport, error) {
+	args := []interface{}{filter.Limit}
+	ndcCondition := ""
+	if len(filter.NDCs) > 0 {
+		args = append(args, pq.Array(filter.NDCs))
+		ndcCondition = "AND c.ndc = ANY($2)"
+	}
+
+	query := fmt.Sprintf(`
+		WITH quantity_counts AS (
+			SELECT c.ndc, c.quantity, COUNT(*) AS fills
+			FROM claims c
+			WHERE c.status = 'paid'
+				AND NOT EXISTS (SELECT 1 FROM reversals r WHERE r.claim_id = c.id)
+			%s
+			GROUP BY c.ndc, c.quantity
+		), ranked AS (
+			SELECT ndc, quantity, fills,
+				ROW_NUMBER() OVER (PARTITION BY ndc ORDER BY fills DESC, quantity) AS rank
+			FROM quantity_counts
+		)
+		SELECT ndc, quantity, fills
+		FROM ranked
+		WHERE rank <= $1
+		ORDER BY ndc, rank`, ndcCondition)
+
+	rows, err := pr.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prescribed quantities: %w", err)
+	}
+	defer rows.Close()
+
+	reports := []models.QuantityReport{}
+	for rows.Next() {
+		var ndc string
+		var quantity models.QuantityCount
+		if err := rows.Scan(&ndc, &quantity.Quantity, &quantity.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan prescribed quantity: %w", err)
+		}
+
+		last := len(reports) - 1
+		if last < 0 || reports[last].NDC != ndc {
+			reports = append(reports, models.QuantityReport{NDC: ndc})
+			last++
+		}
+		reports[last].Quantities = append(reports[last].Quantities, quantity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate prescribed quantities: %w", err)
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"pharmacyclaims/internal/core"
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/repository"
+	"pharmacyclaims/internal/utility"
+)
+
+type AccumulatorService struct {
+	repo      *repository.Postgres
+	logger    *core.Logger
+	validator *utility.Validator
+}
+
+func NewAccumulatorService(repo *repository.Postgres, logger *core.Logger) *AccumulatorService {
+	return &AccumulatorService{
+		repo:      repo,
+		logger:    logger,
+		validator: utility.NewValidator(),
+	}
+}
+
+func (as *AccumulatorService) GetAccumulator(filter models.AccumulatorFilter) (*models.Accumulator, error) {
+	if err := as.validator.ValidateMemberID(filter.MemberID); err != nil {
+		return nil, err
+	}
+
+	if _, err := as.repo.GetMember(filter.MemberID); err != nil {
+		return nil, err
+	}
+
+	if filter.AsOf == nil {
+		today := parseServiceDate("", time.Now())
+		filter.AsOf = &today
+	}
+	if filter.PlanYear == 0 {
+		filter.PlanYear = filter.AsOf.Year()
+	}
+
+	return as.repo.GetAccumulator(filter)
+}
+
+func (as *AccumulatorService) RecomputeAccumulators(filter models.AccumulatorFilter) ([]models.AccumulatorEntry, error) {
+	if filter.MemberID != "" {
+		if err := as.validator.ValidateMemberID(filter.MemberID); err != nil {
+			return nil, err
+		}
+	}
+
+	adjustments, err := as.repo.RecomputeAccumulators(filter, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to recompute accumulators: %w", err)
+	}
+
+	for _, adjustment := range adjustments {
+		as.logger.LogEvent("accumulator_adjusted", map[string]interface{}{
+			"entry_id":   adjustment.ID,
+			"member_id":  adjustment.MemberID,
+			"plan_year":  adjustment.PlanYear,
+			"deductible": adjustment.Deductible,
+			"oop":        adjustment.OOP,
+		})
+	}
+
+	return adjustments, nil
+}
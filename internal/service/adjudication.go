@@ -0,0 +1,343 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/ncpdp"
+	"pharmacyclaims/internal/repository"
+	"pharmacyclaims/internal/utility"
+)
+
+type adjudication struct {
+	claim     *models.Claim
+	pharmacy  *models.Pharmacy
+	member    *models.Member
+	formulary *models.FormularyEntry
+	costShare repository.CostShareFunc
+}
+
+type claimEdit func(cs *ClaimsService, a *adjudication) (*models.Reject, error)
+
+var claimEdits = []claimEdit{
+	editPharmacyActive,
+	editEligibility,
+	editNDC,
+	editFormulary,
+	editFormularyQuantity,
+	editPriorAuth,
+	editStepTherapy,
+	editDuplicate,
+	editDUR,
+	editQuantityLimit,
+	editPricing,
+	editCostShare,
+}
+
+func (cs *ClaimsService) adjudicate(claim *models.Claim, pharmacy *models.Pharmacy) (repository.CostShareFunc, error) {
+	claim.Rejects = nil
+	claim.PriorAuthID = nil
+	a := &adjudication{claim: claim, pharmacy: pharmacy}
+	for _, edit := range claimEdits {
+		reject, err := edit(cs, a)
+		if err != nil {
+			return nil, err
+		}
+		if reject != nil {
+			claim.Rejects = append(claim.Rejects, *reject)
+		}
+	}
+
+	claim.Status = models.ClaimStatusPaid
+	if len(claim.Rejects) > 0 {
+		claim.Status = models.ClaimStatusRejected
+		claim.Pricing = models.ClaimPricing{}
+		claim.CostShare = models.CostShare{}
+		claim.PriorAuthID = nil
+		return nil, nil
+	}
+
+	return a.costShare, nil
+}
+
+func editPharmacyActive(cs *ClaimsService, a *adjudication) (*models.Reject, error) {
+	if a.pharmacy.Active {
+		return nil, nil
+	}
+	return &models.Reject{
+		Code:    ncpdp.RejectPharmacyNotContracted,
+		Message: fmt.Sprintf("pharmacy %s is inactive", a.pharmacy.NPI),
+	}, nil
+}
+
+func editEligibility(cs *ClaimsService, a *adjudication) (*models.Reject, error) {
+	member, err := cs.repo.GetMember(a.claim.MemberID)
+	if errors.Is(err, models.ErrMemberNotFound) {
+		return &models.Reject{
+			Code:    ncpdp.RejectNonMatchedCardholderID,
+			Message: fmt.Sprintf("member %s not found", a.claim.MemberID),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	a.member = member
+
+	serviceDate := a.claim.ServiceDate
+	if serviceDate.Before(member.EffectiveDate) {
+		return &models.Reject{
+			Code:    ncpdp.RejectBeforeCoverageEffective,
+			Message: fmt.Sprintf("coverage for member %s starts %s", member.MemberID, member.EffectiveDate.Format(utility.ServiceDateLayout)),
+		}, nil
+	}
+	if member.TerminationDate != nil && serviceDate.After(*member.TerminationDate) {
+		return &models.Reject{
+			Code:    ncpdp.RejectAfterCoverageTerminated,
+			Message: fmt.Sprintf("coverage for member %s terminated %s", member.MemberID, member.TerminationDate.Format(utility.ServiceDateLayout)),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func editNDC(cs *ClaimsService, a *adjudication) (*models.Reject, error) {
+	if err := cs.validator.ValidateNDC(a.claim.NDC); err != nil {
+		return &models.Reject{Code: ncpdp.RejectProductID, Message: err.Error()}, nil
+	}
+	return nil, nil
+}
+
+func editFormulary(cs *ClaimsService, a *adjudication) (*models.Reject, error) {
+	if a.member == nil {
+		return nil, nil
+	}
+
+	entry, err := cs.repo.GetFormularyEntry(a.member.PlanID, a.claim.NDC)
+	if errors.Is(err, models.ErrNotOnFormulary) {
+		return &models.Reject{
+			Code:    ncpdp.RejectProductNotCovered,
+			Message: fmt.Sprintf("ndc %s is not on the %s formulary", a.claim.NDC, a.member.PlanID),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.formulary = entry
+	return nil, nil
+}
+
+func editFormularyQuantity(cs *ClaimsService, a *adjudication) (*models.Reject, error) {
+	if a.formulary == nil || a.formulary.QuantityLimit == nil || a.claim.Quantity <= *a.formulary.QuantityLimit {
+		return nil, nil
+	}
+	return &models.Reject{
+		Code:    ncpdp.RejectPlanLimitsExceeded,
+		Message: fmt.Sprintf("quantity %g exceeds the %s formulary limit of %g", a.claim.Quantity, a.formulary.PlanID, *a.formulary.QuantityLimit),
+	}, nil
+}
+
+func editPriorAuth(cs *ClaimsService, a *adjudication) (*models.Reject, error) {
+	if a.formulary == nil || !a.formulary.PriorAuthRequired {
+		return nil, nil
+	}
+
+	classes, err := cs.repo.GetDrugClasses([]string{a.claim.NDC})
+	if err != nil {
+		return nil, err
+	}
+
+	priorAuth, err := cs.repo.FindPriorAuth(a.claim, classes[a.claim.NDC])
+	if errors.Is(err, models.ErrPriorAuthNotFound) {
+		return &models.Reject{
+			Code:    ncpdp.RejectPriorAuthRequired,
+			Message: fmt.Sprintf("ndc %s requires prior authorization on the %s formulary", a.claim.NDC, a.formulary.PlanID),
+		}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	a.claim.PriorAuthID = &priorAuth.ID
+	return nil, nil
+}
+
+func editStepTherapy(cs *ClaimsService, a *adjudication) (*models.Reject, error) {
+	if a.formulary == nil || !a.formulary.StepTherapyRequired || a.claim.PriorAuthID != nil {
+		return nil, nil
+	}
+
+	classes, err := cs.repo.GetDrugClasses([]string{a.claim.NDC})
+	if err != nil {
+		return nil, err
+	}
+	drugClass := classes[a.claim.NDC]
+
+	if !a.formulary.PriorAuthRequired {
+		priorAuth, err := cs.repo.FindPriorAuth(a.claim, drugClass)
+		if err == nil {
+			a.claim.PriorAuthID = &priorAuth.ID
+			return nil, nil
+		}
+		if !errors.Is(err, models.ErrPriorAuthNotFound) {
+			return nil, err
+		}
+	}
+
+	met, err := cs.stepTherapyMet(a.claim, drugClass)
+	if err != nil || met {
+		return nil, err
+	}
+
+	return &models.Reject{
+		Code:    ncpdp.RejectStepTherapyRequired,
+		Message: fmt.Sprintf("ndc %s requires step therapy on the %s formulary", a.claim.NDC, a.formulary.PlanID),
+	}, nil
+}
+
+func (cs *ClaimsService) stepTherapyMet(claim *models.Claim, drugClass string) (bool, error) {
+	if drugClass == "" || cs.policy.StepTherapyLookback == 0 {
+		return false, nil
+	}
+
+	history, err := cs.repo.FindMemberClaimHistory(claim, claim.ServiceDate.Add(-cs.policy.StepTherapyLookback))
+	if err != nil || len(history) == 0 {
+		return false, err
+	}
+
+	ndcs := make([]string, len(history))
+	for i, previous := range history {
+		ndcs[i] = previous.NDC
+	}
+	classes, err := cs.repo.GetDrugClasses(ndcs)
+	if err != nil {
+		return false, err
+	}
+
+	for _, previous := range history {
+		if classes[previous.NDC] == drugClass {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func editDuplicate(cs *ClaimsService, a *adjudication) (*models.Reject, error) {
+	claim := a.claim
+	if cs.policy.DuplicateWindow == 0 {
+		return nil, nil
+	}
+
+	duplicate, err := cs.repo.FindDuplicateClaim(claim, cs.policy.DuplicateWindow)
+	if errors.Is(err, models.ErrClaimNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cs.policy.DuplicateAction == DuplicateActionFlag {
+		claim.DuplicateOf = &duplicate.ID
+		return nil, nil
+	}
+
+	return &models.Reject{
+		Code:    ncpdp.RejectDuplicateClaim,
+		Message: fmt.Sprintf("duplicate of claim %s", duplicate.ID),
+	}, nil
+}
+
+func editDUR(cs *ClaimsService, a *adjudication) (*models.Reject, error) {
+	if a.member == nil || cs.policy.DURLookback == 0 {
+		return nil, nil
+	}
+
+	alerts, err := cs.reviewDrugUtilization(a.claim)
+	if err != nil {
+		return nil, err
+	}
+	a.claim.DUR = alerts
+
+	code := ncpdp.RejectRefillTooSoon
+	var messages []string
+	for _, alert := range alerts {
+		if !alert.Hard {
+			continue
+		}
+		messages = append(messages, alert.Message)
+		if alert.ReasonCode != ncpdp.DURReasonEarlyRefill {
+			code = ncpdp.RejectDURReject
+		}
+	}
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	return &models.Reject{Code: code, Message: strings.Join(messages, "; ")}, nil
+}
+
+func editQuantityLimit(cs *ClaimsService, a *adjudication) (*models.Reject, error) {
+	claim := a.claim
+	if cs.policy.MaxClaimQuantity == 0 || claim.Quantity <= cs.policy.MaxClaimQuantity {
+		return nil, nil
+	}
+	return &models.Reject{
+		Code:    ncpdp.RejectPlanLimitsExceeded,
+		Message: fmt.Sprintf("quantity %g exceeds limit of %g", claim.Quantity, cs.policy.MaxClaimQuantity),
+	}, nil
+}
+
+func editPricing(cs *ClaimsService, a *adjudication) (*models.Reject, error) {
+	claim := a.claim
+	if claim.Price <= 0 {
+		return &models.Reject{
+			Code:    ncpdp.RejectGrossAmountDue,
+			Message: "price must be greater than 0",
+		}, nil
+	}
+	if cs.policy.MaxClaimAmount > 0 && claim.Price > cs.policy.MaxClaimAmount {
+		return &models.Reject{
+			Code:    ncpdp.RejectCostExceedsMaximum,
+			Message: fmt.Sprintf("price %.2f exceeds maximum of %.2f", claim.Price, cs.policy.MaxClaimAmount),
+		}, nil
+	}
+	return nil, cs.priceClaim(claim, a.pharmacy)
+}
+
+func editCostShare(cs *ClaimsService, a *adjudication) (*models.Reject, error) {
+	if len(a.claim.Rejects) > 0 || a.member == nil || a.formulary == nil {
+		return nil, nil
+	}
+	costShare, err := cs.costShareClaim(a.claim, a.member, a.formulary.Tier)
+	if err != nil {
+		return nil, err
+	}
+	a.costShare = costShare
+	return nil, nil
+}
+
+func rejectCodes(rejects []models.Reject) []string {
+	codes := make([]string, len(rejects))
+	for i, reject := range rejects {
+		codes[i] = reject.Code
+	}
+	return codes
+}
+
+func paidPricing(claim *models.Claim) *models.ClaimPricing {
+	if claim.Status != models.ClaimStatusPaid {
+		return nil
+	}
+	pricing := claim.Pricing
+	return &pricing
+}
+
+func paidCostShare(claim *models.Claim) *models.CostShare {
+	if claim.Status != models.ClaimStatusPaid {
+		return nil
+	}
+	costShare := claim.CostShare
+	return &costShare
+}
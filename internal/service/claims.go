@@ -1,8 +1,11 @@
 package service
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"pharmacyclaims/internal/core"
@@ -13,17 +16,144 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	DefaultClaimSearchLimit     = 50
+	MaxClaimSearchLimit         = 500
+	DefaultIdempotencyRetention = 24 * time.Hour
+	DefaultDuplicateWindow      = 24 * time.Hour
+	DefaultReversalWindow       = 90 * 24 * time.Hour
+	DefaultReversalOverrideRole = "supervisor"
+	DefaultMaxClaimQuantity     = 10000
+	DefaultMaxClaimAmount       = 25000
+	DefaultDURLookback          = 180 * 24 * time.Hour
+	DefaultStepTherapyLookback  = 365 * 24 * time.Hour
+	DefaultRefillTooSoonPercent = 75
+
+	DuplicateActionReject = "reject"
+	DuplicateActionFlag   = "flag"
+)
+
+type ClaimsPolicy struct {
+	IdempotencyRetention time.Duration
+	DuplicateWindow      time.Duration
+	DuplicateAction      string
+	ReversalWindow       time.Duration
+	ReversalOverrideRole string
+	MaxClaimQuantity     float64
+	MaxClaimAmount       float64
+	PricingFormula       PricingFormula
+	DURLookback          time.Duration
+	StepTherapyLookback  time.Duration
+	RefillTooSoonPercent float64
+}
+
+func DefaultClaimsPolicy() ClaimsPolicy {
+	return ClaimsPolicy{
+		IdempotencyRetention: DefaultIdempotencyRetention,
+		DuplicateWindow:      DefaultDuplicateWindow,
+		DuplicateAction:      DuplicateActionReject,
+		ReversalWindow:       DefaultReversalWindow,
+		ReversalOverrideRole: DefaultReversalOverrideRole,
+		MaxClaimQuantity:     DefaultMaxClaimQuantity,
+		MaxClaimAmount:       DefaultMaxClaimAmount,
+		PricingFormula:       DefaultPricingFormula(),
+		DURLookback:          DefaultDURLookback,
+		StepTherapyLookback:  DefaultStepTherapyLookback,
+		RefillTooSoonPercent: DefaultRefillTooSoonPercent,
+	}
+}
+
+type ClaimsRepositoryInterface interface {
+	GetPharmacyByNPI(npi string) (*models.Pharmacy, error)
+	GetMember(memberID string) (*models.Member, error)
+	GetFormularyEntry(planID, ndc string) (*models.FormularyEntry, error)
+	GetBenefitPlan(planID string) (*models.BenefitPlan, error)
+	GetDrugPrice(ndc string, serviceDate time.Time) (*models.DrugPrice, error)
+	GetDrugClasses(ndcs []string) (map[string]string, error)
+	ListDrugInteractions(drugClass string) ([]models.DrugInteraction, error)
+	FindPriorAuth(claim *models.Claim, drugClass string) (*models.PriorAuth, error)
+	FindDuplicateClaim(claim *models.Claim, window time.Duration) (*models.Claim, error)
+	FindMemberClaimHistory(claim *models.Claim, since time.Time) ([]models.Claim, error)
+	FindClaimForReversal(match models.ReversalMatch) (*models.Claim, error)
+	CreateClaim(claim *models.Claim, costShare repository.CostShareFunc) error
+	CreateClaimWithIdempotencyKey(claim *models.Claim, record *models.IdempotencyRecord, costShare repository.CostShareFunc) error
+	GetIdempotencyRecord(key string, now time.Time) (*models.IdempotencyRecord, error)
+	DeleteExpiredIdempotencyKeys(now time.Time) (int64, error)
+	GetClaimByID(id uuid.UUID) (*models.Claim, error)
+	GetClaimDetailsByID(id uuid.UUID) (*models.ClaimDetails, error)
+	SearchClaims(filter models.ClaimSearchFilter) ([]models.ClaimDetails, error)
+	ReverseClaim(reversal *models.Reversal) error
+	RebillClaim(reversal *models.Reversal, claim *models.Claim, costShare repository.CostShareFunc) error
+	GetContractForChain(chain string, serviceDate time.Time) (*models.Contract, error)
+}
+
 type ClaimsService struct {
-	repo      *repository.Postgres
+	repo      ClaimsRepositoryInterface
 	logger    *core.Logger
 	validator *utility.Validator
+	policy    ClaimsPolicy
 }
 
-func NewClaimsService(repo *repository.Postgres, logger *core.Logger) *ClaimsService {
+func NewClaimsService(repo ClaimsRepositoryInterface, logger *core.Logger) *ClaimsService {
+	return NewClaimsServiceWithPolicy(repo, logger, DefaultClaimsPolicy())
+}
+
+func NewClaimsServiceWithPolicy(repo ClaimsRepositoryInterface, logger *core.Logger, policy ClaimsPolicy) *ClaimsService {
+	if policy.IdempotencyRetention <= 0 {
+		log.Printf("Invalid idempotency retention %v, using default %v", policy.IdempotencyRetention, DefaultIdempotencyRetention)
+		policy.IdempotencyRetention = DefaultIdempotencyRetention
+	}
+	if policy.DuplicateWindow < 0 {
+		log.Printf("Invalid duplicate window %v, disabling duplicate detection", policy.DuplicateWindow)
+		policy.DuplicateWindow = 0
+	}
+	if policy.DuplicateAction != DuplicateActionReject && policy.DuplicateAction != DuplicateActionFlag {
+		log.Printf("Invalid duplicate action %q, using %q", policy.DuplicateAction, DuplicateActionReject)
+		policy.DuplicateAction = DuplicateActionReject
+	}
+	if policy.ReversalWindow < 0 {
+		log.Printf("Invalid reversal window %v, disabling reversal window", policy.ReversalWindow)
+		policy.ReversalWindow = 0
+	}
+	if policy.MaxClaimQuantity < 0 {
+		log.Printf("Invalid max claim quantity %v, disabling quantity limit", policy.MaxClaimQuantity)
+		policy.MaxClaimQuantity = 0
+	}
+	if policy.MaxClaimAmount < 0 {
+		log.Printf("Invalid max claim amount %v, disabling amount limit", policy.MaxClaimAmount)
+		policy.MaxClaimAmount = 0
+	}
+	if policy.StepTherapyLookback < 0 {
+		log.Printf("Invalid step therapy lookback %v, ignoring claim history for step therapy", policy.StepTherapyLookback)
+		policy.StepTherapyLookback = 0
+	}
+	if policy.DURLookback < 0 {
+		log.Printf("Invalid DUR lookback %v, disabling drug utilization review", policy.DURLookback)
+		policy.DURLookback = 0
+	}
+	if policy.RefillTooSoonPercent < 0 || policy.RefillTooSoonPercent > 100 {
+		log.Printf("Invalid refill too soon percent %v, using default %v", policy.RefillTooSoonPercent, DefaultRefillTooSoonPercent)
+		policy.RefillTooSoonPercent = DefaultRefillTooSoonPercent
+	}
+	validator := utility.NewValidator()
+	if err := validator.ValidatePriceBasis(policy.PricingFormula.Basis); err != nil {
+		log.Printf("Invalid pricing basis %q, using %q", policy.PricingFormula.Basis, DefaultPricingBasis)
+		policy.PricingFormula.Basis = DefaultPricingBasis
+	}
+	if policy.PricingFormula.Percent <= -100 {
+		log.Printf("Invalid pricing percent %v, using default %v", policy.PricingFormula.Percent, DefaultPricingPercent)
+		policy.PricingFormula.Percent = DefaultPricingPercent
+	}
+	if policy.PricingFormula.DispensingFee < 0 {
+		log.Printf("Invalid dispensing fee %v, using 0", policy.PricingFormula.DispensingFee)
+		policy.PricingFormula.DispensingFee = 0
+	}
+
 	return &ClaimsService{
 		repo:      repo,
 		logger:    logger,
-		validator: utility.NewValidator(),
+		validator: validator,
+		policy:    policy,
 	}
 }
 
@@ -32,55 +162,142 @@ func (cs *ClaimsService) SubmitClaim(request models.ClaimRequest) (*models.Claim
 		return nil, err
 	}
 
+	var requestHash string
+	if request.IdempotencyKey != "" {
+		requestHash = hashClaimRequest(request)
+		response, err := cs.replayIdempotentRequest(request.IdempotencyKey, requestHash)
+		if err == nil {
+			return response, nil
+		}
+		if !errors.Is(err, models.ErrIdempotencyKeyNotFound) {
+			return nil, err
+		}
+	}
+
 	pharmacy, err := cs.repo.GetPharmacyByNPI(request.NPI)
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate pharmacy: %w", err)
-	}
-	if pharmacy == nil {
-		return nil, fmt.Errorf("pharmacy with NPI %s not found", request.NPI)
+		return nil, err
 	}
 
+	now := time.Now()
 	claim := &models.Claim{
-		ID:        uuid.New(),
-		NDC:       request.NDC,
-		Quantity:  request.Quantity,
-		NPI:       request.NPI,
-		Price:     request.Price,
-		Timestamp: models.CustomTime{Time: time.Now()},
+		ID:                 uuid.New(),
+		NDC:                request.NDC,
+		Quantity:           request.Quantity,
+		DaysSupply:         request.DaysSupply,
+		DAWCode:            request.DAWCode,
+		FillNumber:         request.FillNumber,
+		PrescriptionNumber: request.PrescriptionNumber,
+		DateWritten:        parseDateWritten(request.DateWritten),
+		PrescriberNPI:      request.PrescriberNPI,
+		NPI:                request.NPI,
+		Price:              request.Price,
+		Timestamp:          models.CustomTime{Time: now},
+		MemberID:           request.MemberID,
+		ServiceDate:        parseServiceDate(request.ServiceDate, now),
+	}
+
+	costShare, err := cs.adjudicate(claim, pharmacy)
+	if err != nil {
+		return nil, err
 	}
 
-	err = cs.repo.CreateClaim(claim)
+	response := &models.ClaimResponse{
+		Status:      claim.Status,
+		ClaimID:     claim.ID,
+		Rejects:     claim.Rejects,
+		DUR:         claim.DUR,
+		DuplicateOf: claim.DuplicateOf,
+		Pricing:     paidPricing(claim),
+		CostShare:   paidCostShare(claim),
+		PriorAuthID: claim.PriorAuthID,
+	}
+
+	if request.IdempotencyKey == "" {
+		err = cs.repo.CreateClaim(claim, costShare)
+	} else {
+		err = cs.repo.CreateClaimWithIdempotencyKey(claim, &models.IdempotencyRecord{
+			Key:         request.IdempotencyKey,
+			RequestHash: requestHash,
+			ClaimID:     claim.ID,
+			Response:    *response,
+			CreatedAt:   claim.Timestamp.Time,
+			ExpiresAt:   claim.Timestamp.Add(cs.policy.IdempotencyRetention),
+		}, costShare)
+		if errors.Is(err, models.ErrIdempotencyKeyInUse) {
+			return cs.replayIdempotentRequest(request.IdempotencyKey, requestHash)
+		}
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to create claim: %w", err)
 	}
+	response.CostShare = paidCostShare(claim)
 
 	cs.logger.LogEvent("claim_submitted", map[string]interface{}{
-		"claim_id": claim.ID.String(),
-		"ndc":      claim.NDC,
-		"quantity": claim.Quantity,
-		"npi":      claim.NPI,
-		"price":    claim.Price,
-		"chain":    pharmacy.Chain,
+		"claim_id":            claim.ID.String(),
+		"ndc":                 claim.NDC,
+		"quantity":            claim.Quantity,
+		"npi":                 claim.NPI,
+		"price":               claim.Price,
+		"member_id":           claim.MemberID,
+		"service_date":        claim.ServiceDate.Format(utility.ServiceDateLayout),
+		"days_supply":         claim.DaysSupply,
+		"fill_number":         claim.FillNumber,
+		"prescription_number": claim.PrescriptionNumber,
+		"prescriber_npi":      claim.PrescriberNPI,
+		"chain":               pharmacy.Chain,
+		"status":              claim.Status,
+		"pricing_basis":       claim.Pricing.Basis,
+		"allowed_amount":      claim.Pricing.Total,
+		"patient_pay":         claim.CostShare.PatientPay,
+		"plan_paid":           claim.CostShare.PlanPaid,
+		"reject_codes":        rejectCodes(claim.Rejects),
+		"dur_codes":           durCodes(claim.DUR),
+		"prior_auth_id":       claim.PriorAuthID,
+		"idempotency_key":     request.IdempotencyKey,
+		"duplicate_of":        claim.DuplicateOf,
 	})
 
-	return &models.ClaimResponse{
-		Status:  "claim submitted",
-		ClaimID: claim.ID,
-	}, nil
+	return response, nil
 }
 
 func (cs *ClaimsService) ReverseClaim(request models.ReversalRequest) (*models.ReversalResponse, error) {
+	if err := cs.ValidateReversal(request); err != nil {
+		return nil, err
+	}
+
 	claim, err := cs.repo.GetClaimByID(request.ClaimID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get claim: %w", err)
+		return nil, err
 	}
-	if claim == nil {
-		return nil, fmt.Errorf("claim with ID %s not found", request.ClaimID.String())
+
+	if err := matchReversalToClaim(request, claim); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	overridden, err := cs.checkReversalWindow(claim, request.Role, now)
+	if err != nil {
+		return nil, err
 	}
 
-	err = cs.repo.ReverseClaim(request.ClaimID, request.Reason)
+	reversal := &models.Reversal{
+		ID:             uuid.New(),
+		ClaimID:        claim.ID,
+		Timestamp:      models.CustomTime{Time: now},
+		Reason:         request.Reason,
+		ReasonCode:     request.ReasonCode,
+		RequestedBy:    request.RequestedBy,
+		Source:         request.Source,
+		WindowOverride: overridden,
+	}
+	if reversal.Source == "" {
+		reversal.Source = models.ReversalSourceAPI
+	}
+
+	err = cs.repo.ReverseClaim(reversal)
 	if err != nil {
-		return nil, fmt.Errorf("failed to reverse claim: %w", err)
+		return nil, err
 	}
 
 	pharmacy, err := cs.repo.GetPharmacyByNPI(claim.NPI)
@@ -94,7 +311,15 @@ func (cs *ClaimsService) ReverseClaim(request models.ReversalRequest) (*models.R
 		"original_quantity": claim.Quantity,
 		"original_npi":      claim.NPI,
 		"original_price":    claim.Price,
-		"reason":            request.Reason,
+		"member_id":         claim.MemberID,
+		"patient_pay":       claim.CostShare.PatientPay,
+		"reversal_id":       reversal.ID.String(),
+		"reason":            reversal.Reason,
+		"reason_code":       reversal.ReasonCode,
+		"requested_by":      reversal.RequestedBy,
+		"source":            reversal.Source,
+		"window_override":   overridden,
+		"role":              request.Role,
 	}
 	if pharmacy != nil {
 		logPayload["chain"] = pharmacy.Chain
@@ -103,11 +328,314 @@ func (cs *ClaimsService) ReverseClaim(request models.ReversalRequest) (*models.R
 	cs.logger.LogEvent("claim_reversed", logPayload)
 
 	return &models.ReversalResponse{
-		Status:  "claim reversed",
-		ClaimID: claim.ID,
+		Status:         "claim reversed",
+		ClaimID:        claim.ID,
+		ReversalID:     reversal.ID,
+		Timestamp:      &reversal.Timestamp.Time,
+		Reason:         reversal.Reason,
+		ReasonCode:     reversal.ReasonCode,
+		RequestedBy:    reversal.RequestedBy,
+		WindowOverride: reversal.WindowOverride,
 	}, nil
 }
 
+func (cs *ClaimsService) RebillClaim(request models.RebillRequest) (*models.RebillResponse, error) {
+	if err := cs.ValidateRebill(request); err != nil {
+		return nil, err
+	}
+
+	original, err := cs.repo.GetClaimByID(request.ClaimID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := matchReversalToClaim(models.ReversalRequest{NPI: request.NPI}, original); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	overridden, err := cs.checkReversalWindow(original, request.Role, now)
+	if err != nil {
+		return nil, err
+	}
+
+	pharmacy, err := cs.repo.GetPharmacyByNPI(request.NPI)
+	if err != nil {
+		return nil, err
+	}
+
+	reversal := &models.Reversal{
+		ID:             uuid.New(),
+		ClaimID:        original.ID,
+		Timestamp:      models.CustomTime{Time: now},
+		Reason:         request.Reason,
+		ReasonCode:     request.ReasonCode,
+		RequestedBy:    request.RequestedBy,
+		Source:         models.ReversalSourceAPI,
+		WindowOverride: overridden,
+	}
+
+	serviceDate := original.ServiceDate
+	if request.ServiceDate != "" {
+		serviceDate = parseServiceDate(request.ServiceDate, now)
+	}
+
+	claim := &models.Claim{
+		ID:                 uuid.New(),
+		NDC:                request.NDC,
+		Quantity:           request.Quantity,
+		DaysSupply:         request.DaysSupply,
+		DAWCode:            request.DAWCode,
+		FillNumber:         request.FillNumber,
+		PrescriptionNumber: request.PrescriptionNumber,
+		DateWritten:        parseDateWritten(request.DateWritten),
+		PrescriberNPI:      request.PrescriberNPI,
+		NPI:                request.NPI,
+		Price:              request.Price,
+		Timestamp:          models.CustomTime{Time: now},
+		MemberID:           request.MemberID,
+		ServiceDate:        serviceDate,
+		OriginalClaimID:    &original.ID,
+	}
+
+	if claim.DateWritten != nil {
+		if err := cs.validator.ValidateDateWritten(*claim.DateWritten, claim.ServiceDate); err != nil {
+			return nil, err
+		}
+	}
+
+	costShare, err := cs.adjudicate(claim, pharmacy)
+	if err != nil {
+		return nil, err
+	}
+	if claim.Status == models.ClaimStatusRejected {
+		return nil, models.NewRejectedError(claim.Rejects)
+	}
+
+	if err := cs.repo.RebillClaim(reversal, claim, costShare); err != nil {
+		return nil, err
+	}
+
+	cs.logger.LogEvent("claim_rebilled", map[string]interface{}{
+		"original_claim_id":   original.ID.String(),
+		"claim_id":            claim.ID.String(),
+		"reversal_id":         reversal.ID.String(),
+		"original_ndc":        original.NDC,
+		"original_quantity":   original.Quantity,
+		"original_price":      original.Price,
+		"ndc":                 claim.NDC,
+		"quantity":            claim.Quantity,
+		"npi":                 claim.NPI,
+		"price":               claim.Price,
+		"member_id":           claim.MemberID,
+		"service_date":        claim.ServiceDate.Format(utility.ServiceDateLayout),
+		"days_supply":         claim.DaysSupply,
+		"fill_number":         claim.FillNumber,
+		"prescription_number": claim.PrescriptionNumber,
+		"prescriber_npi":      claim.PrescriberNPI,
+		"pricing_basis":       claim.Pricing.Basis,
+		"allowed_amount":      claim.Pricing.Total,
+		"patient_pay":         claim.CostShare.PatientPay,
+		"plan_paid":           claim.CostShare.PlanPaid,
+		"chain":               pharmacy.Chain,
+		"reason":              reversal.Reason,
+		"reason_code":         reversal.ReasonCode,
+		"requested_by":        reversal.RequestedBy,
+		"window_override":     overridden,
+		"role":                request.Role,
+		"duplicate_of":        claim.DuplicateOf,
+		"prior_auth_id":       claim.PriorAuthID,
+	})
+
+	return &models.RebillResponse{
+		Status:          "claim rebilled",
+		OriginalClaimID: original.ID,
+		ClaimID:         claim.ID,
+		ReversalID:      reversal.ID,
+		DUR:             claim.DUR,
+		DuplicateOf:     claim.DuplicateOf,
+		Pricing:         paidPricing(claim),
+		CostShare:       paidCostShare(claim),
+		PriorAuthID:     claim.PriorAuthID,
+	}, nil
+}
+
+func (cs *ClaimsService) checkReversalWindow(claim *models.Claim, role string, now time.Time) (bool, error) {
+	if cs.policy.ReversalWindow == 0 || now.Sub(claim.Timestamp.Time) <= cs.policy.ReversalWindow {
+		return false, nil
+	}
+
+	if cs.policy.ReversalOverrideRole != "" && role == cs.policy.ReversalOverrideRole {
+		return true, nil
+	}
+
+	return false, fmt.Errorf("%w: claim %s submitted %s, window is %v", models.ErrReversalWindow, claim.ID, claim.Timestamp.Format(time.RFC3339), cs.policy.ReversalWindow)
+}
+
+func parseServiceDate(value string, now time.Time) time.Time {
+	if value == "" {
+		value = now.Format(utility.ServiceDateLayout)
+	}
+	serviceDate, _ := time.Parse(utility.ServiceDateLayout, value)
+	return serviceDate
+}
+
+func parseDateWritten(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	dateWritten, err := time.Parse(utility.ServiceDateLayout, value)
+	if err != nil {
+		return nil
+	}
+	return &dateWritten
+}
+
+func matchReversalToClaim(request models.ReversalRequest, claim *models.Claim) error {
+	if request.NPI != claim.NPI {
+		return fmt.Errorf("%w: npi %s did not submit claim %s", models.ErrReversalMismatch, request.NPI, claim.ID)
+	}
+	if request.NDC != "" && request.NDC != claim.NDC {
+		return fmt.Errorf("%w: ndc %s does not match claim %s", models.ErrReversalMismatch, request.NDC, claim.ID)
+	}
+	if request.ServiceDate != "" && request.ServiceDate != claim.ServiceDate.Format(utility.ServiceDateLayout) {
+		return fmt.Errorf("%w: service_date %s does not match claim %s", models.ErrReversalMismatch, request.ServiceDate, claim.ID)
+	}
+	return nil
+}
+
 func (cs *ClaimsService) ValidateClaim(request models.ClaimRequest) error {
 	return cs.validator.ValidateClaimRequest(request)
 }
+
+func (cs *ClaimsService) ValidateReversal(request models.ReversalRequest) error {
+	return cs.validator.ValidateReversalRequest(request)
+}
+
+func (cs *ClaimsService) ValidateRebill(request models.RebillRequest) error {
+	return cs.validator.ValidateRebillRequest(request)
+}
+
+func (cs *ClaimsService) FindClaimForReversal(match models.ReversalMatch) (*models.Claim, error) {
+	return cs.repo.FindClaimForReversal(match)
+}
+
+func (cs *ClaimsService) GetClaim(id uuid.UUID) (*models.ClaimDetails, error) {
+	return cs.repo.GetClaimDetailsByID(id)
+}
+
+func (cs *ClaimsService) ValidateClaimSearch(filter models.ClaimSearchFilter) error {
+	if filter.NPI != "" {
+		if err := cs.validator.ValidateNPI(filter.NPI); err != nil {
+			return err
+		}
+	}
+	if filter.NDC != "" {
+		if err := cs.validator.ValidateNDC(filter.NDC); err != nil {
+			return err
+		}
+	}
+	if filter.DaysSupply != nil {
+		if err := cs.validator.ValidateDaysSupply(*filter.DaysSupply); err != nil {
+			return err
+		}
+	}
+	if filter.DAWCode != "" {
+		if err := cs.validator.ValidateDAWCode(filter.DAWCode); err != nil {
+			return err
+		}
+	}
+	if filter.FillNumber != nil {
+		if err := cs.validator.ValidateFillNumber(*filter.FillNumber); err != nil {
+			return err
+		}
+	}
+	if filter.PrescriptionNumber != "" {
+		if err := cs.validator.ValidatePrescriptionNumber(filter.PrescriptionNumber); err != nil {
+			return err
+		}
+	}
+	if filter.PrescriberNPI != "" {
+		if err := cs.validator.ValidatePrescriberNPI(filter.PrescriberNPI); err != nil {
+			return err
+		}
+	}
+	if filter.Status != "" && filter.Status != models.ClaimStatusPaid && filter.Status != models.ClaimStatusRejected {
+		return models.NewValidationError("status", fmt.Sprintf("invalid status: must be %s or %s", models.ClaimStatusPaid, models.ClaimStatusRejected))
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return models.NewValidationError("from", "invalid date range: from must be before to")
+	}
+	if filter.Limit < 0 || filter.Limit > MaxClaimSearchLimit {
+		return models.NewValidationError("limit", fmt.Sprintf("invalid limit: must be between 1 and %d", MaxClaimSearchLimit))
+	}
+	if filter.Cursor != "" {
+		if _, err := decodeClaimCursor(filter.Cursor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cs *ClaimsService) SearchClaims(filter models.ClaimSearchFilter) (*models.ClaimSearchResponse, error) {
+	if err := cs.ValidateClaimSearch(filter); err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	if limit == 0 {
+		limit = DefaultClaimSearchLimit
+	}
+
+	if filter.Cursor != "" {
+		cursor, _ := decodeClaimCursor(filter.Cursor)
+		filter.After = cursor
+	}
+	filter.Limit = limit + 1
+
+	claims, err := cs.repo.SearchClaims(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search claims: %w", err)
+	}
+
+	response := &models.ClaimSearchResponse{Claims: claims}
+	if len(claims) > limit {
+		response.Claims = claims[:limit]
+		last := response.Claims[limit-1]
+		response.NextCursor = encodeClaimCursor(models.ClaimCursor{
+			Timestamp: last.Timestamp.Time,
+			ID:        last.ID,
+		})
+	}
+
+	return response, nil
+}
+
+func encodeClaimCursor(cursor models.ClaimCursor) string {
+	raw := cursor.Timestamp.Format(time.RFC3339Nano) + "|" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeClaimCursor(value string) (*models.ClaimCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, models.NewValidationError("cursor", "invalid cursor")
+	}
+
+	parts := strings.SplitN(string(raw), "|", 2)
+	if len(parts) != 2 {
+		return nil, models.NewValidationError("cursor", "invalid cursor")
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, models.NewValidationError("cursor", "invalid cursor")
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, models.NewValidationError("cursor", "invalid cursor")
+	}
+
+	return &models.ClaimCursor{Timestamp: timestamp, ID: id}, nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"pharmacyclaims/internal/core"
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/repository"
+	"pharmacyclaims/internal/utility"
+)
+
+type ContractService struct {
+	repo      *repository.Postgres
+	logger    *core.Logger
+	validator *utility.Validator
+}
+
+func NewContractService(repo *repository.Postgres, logger *core.Logger) *ContractService {
+	return &ContractService{
+		repo:      repo,
+		logger:    logger,
+		validator: utility.NewValidator(),
+	}
+}
+
+func (cs *ContractService) ValidateContract(request models.ContractRequest) error {
+	return cs.validator.ValidateContractRequest(request)
+}
+
+func (cs *ContractService) ListContracts(filter models.ContractFilter) ([]models.Contract, error) {
+	if filter.Chain != "" {
+		if err := cs.validator.ValidateChain(filter.Chain); err != nil {
+			return nil, err
+		}
+	}
+
+	contracts, err := cs.repo.ListContracts(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contracts: %w", err)
+	}
+
+	return contracts, nil
+}
+
+func (cs *ContractService) GetContract(id int) (*models.Contract, error) {
+	return cs.repo.GetContract(id)
+}
+
+func (cs *ContractService) CreateContract(request models.ContractRequest) (*models.Contract, error) {
+	if err := cs.ValidateContract(request); err != nil {
+		return nil, err
+	}
+
+	contract := &models.Contract{ContractTerms: contractTerms(request)}
+	if err := cs.repo.CreateContract(contract, request.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	cs.logContractChange("contract_created", contract, request.RequestedBy)
+
+	return contract, nil
+}
+
+func (cs *ContractService) UpdateContract(id int, request models.ContractRequest) (*models.Contract, error) {
+	if err := cs.ValidateContract(request); err != nil {
+		return nil, err
+	}
+
+	contract, err := cs.GetContract(id)
+	if err != nil {
+		return nil, err
+	}
+
+	contract.ContractTerms = contractTerms(request)
+	if err := cs.repo.UpdateContract(contract, request.RequestedBy); err != nil {
+		return nil, err
+	}
+
+	cs.logContractChange("contract_updated", contract, request.RequestedBy)
+
+	return contract, nil
+}
+
+func (cs *ContractService) GetContractHistory(id int) ([]models.ContractChange, error) {
+	if _, err := cs.GetContract(id); err != nil {
+		return nil, err
+	}
+
+	changes, err := cs.repo.ListContractHistory(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract history: %w", err)
+	}
+
+	return changes, nil
+}
+
+func contractTerms(request models.ContractRequest) models.ContractTerms {
+	terms := models.ContractTerms{
+		Chain:         request.Chain,
+		Basis:         request.Basis,
+		Percent:       request.Percent,
+		DispensingFee: request.DispensingFee,
+	}
+	terms.EffectiveFrom, _ = time.Parse(utility.ServiceDateLayout, request.EffectiveFrom)
+	if request.EffectiveTo != "" {
+		effectiveTo, _ := time.Parse(utility.ServiceDateLayout, request.EffectiveTo)
+		terms.EffectiveTo = &effectiveTo
+	}
+	return terms
+}
+
+func (cs *ContractService) logContractChange(event string, contract *models.Contract, requestedBy string) {
+	payload := map[string]interface{}{
+		"contract_id":    contract.ID,
+		"chain":          contract.Chain,
+		"basis":          contract.Basis,
+		"percent":        contract.Percent,
+		"dispensing_fee": contract.DispensingFee,
+		"effective_from": contract.EffectiveFrom.Format(utility.ServiceDateLayout),
+		"requested_by":   requestedBy,
+	}
+	if contract.EffectiveTo != nil {
+		payload["effective_to"] = contract.EffectiveTo.Format(utility.ServiceDateLayout)
+	}
+
+	cs.logger.LogEvent(event, payload)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"errors"
+	"math"
+
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/repository"
+)
+
+func (cs *ClaimsService) costShareClaim(claim *models.Claim, member *models.Member, tier int) (repository.CostShareFunc, error) {
+	claim.CostShare = models.CostShare{PlanPaid: claim.Pricing.Total}
+
+	plan, err := cs.repo.GetBenefitPlan(member.PlanID)
+	if errors.Is(err, models.ErrPlanNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := claim.Pricing.Total
+	return func(accumulator models.Accumulator) models.CostShare {
+		return CalculateCostShare(plan, tier, accumulator, allowed)
+	}, nil
+}
+
+func CalculateCostShare(plan *models.BenefitPlan, tier int, accumulator models.Accumulator, allowed float64) models.CostShare {
+	var share models.CostShare
+
+	deductibleLeft := math.Max(plan.Deductible-accumulator.DeductibleMet, 0)
+	share.Deductible = roundCents(math.Min(allowed, deductibleLeft))
+	remaining := allowed - share.Deductible
+
+	if tierShare := benefitPlanTier(plan, tier); tierShare != nil {
+		if tierShare.Copay > 0 {
+			share.Copay = roundCents(math.Min(tierShare.Copay, remaining))
+		} else {
+			share.Coinsurance = roundCents(remaining * tierShare.CoinsurancePercent / 100)
+		}
+	}
+
+	if plan.OOPMax != nil {
+		excess := roundCents(share.Deductible + share.Copay + share.Coinsurance - math.Max(*plan.OOPMax-accumulator.OOPMet, 0))
+		for _, amount := range []*float64{&share.Coinsurance, &share.Copay, &share.Deductible} {
+			if excess <= 0 {
+				break
+			}
+			cut := math.Min(*amount, excess)
+			*amount = roundCents(*amount - cut)
+			excess = roundCents(excess - cut)
+		}
+	}
+
+	share.PatientPay = roundCents(share.Deductible + share.Copay + share.Coinsurance)
+	share.PlanPaid = roundCents(allowed - share.PatientPay)
+	return share
+}
+
+func benefitPlanTier(plan *models.BenefitPlan, tier int) *models.BenefitPlanTier {
+	for i := range plan.Tiers {
+		if plan.Tiers[i].Tier == tier {
+			return &plan.Tiers[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"fmt"
+	"math"
+
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/ncpdp"
+	"pharmacyclaims/internal/utility"
+)
+
+const DefaultDURDaysSupply = 30
+
+var interactionSignificance = map[string]string{
+	models.DURSeverityMajor:    ncpdp.ClinicalSignificanceMajor,
+	models.DURSeverityModerate: ncpdp.ClinicalSignificanceModerate,
+	models.DURSeverityMinor:    ncpdp.ClinicalSignificanceMinor,
+}
+
+func (cs *ClaimsService) reviewDrugUtilization(claim *models.Claim) ([]models.DURAlert, error) {
+	history, err := cs.repo.FindMemberClaimHistory(claim, claim.ServiceDate.Add(-cs.policy.DURLookback))
+	if err != nil {
+		return nil, err
+	}
+	if len(history) == 0 {
+		return nil, nil
+	}
+
+	ndcs := []string{claim.NDC}
+	for _, previous := range history {
+		ndcs = append(ndcs, previous.NDC)
+	}
+	classes, err := cs.repo.GetDrugClasses(ndcs)
+	if err != nil {
+		return nil, err
+	}
+
+	var interactions []models.DrugInteraction
+	if drugClass := classes[claim.NDC]; drugClass != "" {
+		if interactions, err = cs.repo.ListDrugInteractions(drugClass); err != nil {
+			return nil, err
+		}
+	}
+
+	return ReviewDrugUtilization(claim, history, classes, interactions, cs.policy.RefillTooSoonPercent), nil
+}
+
+func ReviewDrugUtilization(claim *models.Claim, history []models.Claim, classes map[string]string, interactions []models.DrugInteraction, refillTooSoonPercent float64) []models.DURAlert {
+	var alerts []models.DURAlert
+	seen := make(map[string]bool)
+	drugClass := classes[claim.NDC]
+
+	for _, previous := range history {
+		daysSupply := previous.DaysSupply
+		if daysSupply <= 0 {
+			daysSupply = DefaultDURDaysSupply
+		}
+		elapsed := int(claim.ServiceDate.Sub(previous.ServiceDate).Hours() / 24)
+		active := elapsed < daysSupply
+
+		var alert *models.DURAlert
+		switch {
+		case previous.NDC == claim.NDC:
+			required := int(math.Ceil(float64(daysSupply) * refillTooSoonPercent / 100))
+			if refillTooSoonPercent <= 0 || elapsed >= required {
+				break
+			}
+			alert = &models.DURAlert{
+				ReasonCode: ncpdp.DURReasonEarlyRefill,
+				Hard:       true,
+				Message:    fmt.Sprintf("refill too soon: %d of %d days elapsed since fill on %s", elapsed, required, previous.ServiceDate.Format(utility.ServiceDateLayout)),
+			}
+		case active && drugClass != "" && classes[previous.NDC] == drugClass:
+			alert = &models.DURAlert{
+				ReasonCode:           ncpdp.DURReasonTherapeuticDuplication,
+				ClinicalSignificance: ncpdp.ClinicalSignificanceModerate,
+				Message:              fmt.Sprintf("therapeutic duplication: ndc %s is also %s", previous.NDC, drugClass),
+			}
+		case active:
+			interaction := findDrugInteraction(interactions, drugClass, classes[previous.NDC])
+			if interaction == nil {
+				break
+			}
+			alert = &models.DURAlert{
+				ReasonCode:           ncpdp.DURReasonDrugInteraction,
+				ClinicalSignificance: interactionSignificance[interaction.Severity],
+				Hard:                 interaction.Severity == models.DURSeverityMajor,
+				Message:              fmt.Sprintf("%s interaction with ndc %s: %s", interaction.Severity, previous.NDC, interaction.Description),
+			}
+		}
+
+		if alert == nil || seen[alert.ReasonCode+previous.NDC] {
+			continue
+		}
+		seen[alert.ReasonCode+previous.NDC] = true
+
+		alert.OtherClaimID = previous.ID
+		alert.OtherNDC = previous.NDC
+		alert.OtherPharmacy = previous.NPI != claim.NPI
+		alert.PreviousFillDate = previous.ServiceDate
+		alert.PreviousQuantity = previous.Quantity
+		alerts = append(alerts, *alert)
+	}
+
+	return alerts
+}
+
+func findDrugInteraction(interactions []models.DrugInteraction, classA, classB string) *models.DrugInteraction {
+	if classA == "" || classB == "" {
+		return nil
+	}
+	for i, interaction := range interactions {
+		if (interaction.ClassA == classA && interaction.ClassB == classB) || (interaction.ClassA == classB && interaction.ClassB == classA) {
+			return &interactions[i]
+		}
+	}
+	return nil
+}
+
+func durCodes(alerts []models.DURAlert) []string {
+	codes := make([]string, len(alerts))
+	for i, alert := range alerts {
+		codes[i] = alert.ReasonCode
+	}
+	return codes
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"pharmacyclaims/internal/models"
+)
+
+func (cs *ClaimsService) replayIdempotentRequest(key, requestHash string) (*models.ClaimResponse, error) {
+	record, err := cs.repo.GetIdempotencyRecord(key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if record.RequestHash != requestHash {
+		return nil, fmt.Errorf("%w: %s", models.ErrIdempotencyConflict, key)
+	}
+
+	cs.logger.LogEvent("claim_replayed", map[string]interface{}{
+		"claim_id":        record.ClaimID.String(),
+		"idempotency_key": key,
+	})
+
+	return &record.Response, nil
+}
+
+func (cs *ClaimsService) PurgeExpiredIdempotencyKeys() (int64, error) {
+	return cs.repo.DeleteExpiredIdempotencyKeys(time.Now())
+}
+
+func hashClaimRequest(request models.ClaimRequest) string {
+	payload, _ := json.Marshal(request)
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
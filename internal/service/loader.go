@@ -3,14 +3,20 @@ package service
 import (
 	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"pharmacyclaims/internal/core"
 	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/ndc"
+	"pharmacyclaims/internal/nppes"
 	"pharmacyclaims/internal/repository"
 	"pharmacyclaims/internal/utility"
 )
@@ -89,6 +95,99 @@ func (ls *LoaderService) LoadPharmaciesFromData(dataDir string) error {
 	return nil
 }
 
+func (ls *LoaderService) LoadNPPESFromData(dataDir string) error {
+	count, err := ls.repo.CountNPIRecords()
+	if err != nil {
+		log.Printf("Warning: Failed to check NPI registry count: %v", err)
+	} else if count > 0 {
+		log.Printf("NPI registry already loaded (%d records found), skipping data loading", count)
+		return nil
+	}
+
+	targetDir := filepath.Join(dataDir, "nppes")
+	files, err := filepath.Glob(filepath.Join(targetDir, "*.csv"))
+	if err != nil {
+		return fmt.Errorf("failed to read NPPES directory: %v", err)
+	}
+
+	if len(files) == 0 {
+		log.Printf("No NPPES files found in %s", targetDir)
+		return nil
+	}
+
+	totalLoaded := 0
+	for _, filename := range files {
+		loaded, err := ls.importNPPESFile(filename)
+		if err != nil {
+			log.Printf("Warning: Failed to load NPPES records from %s: %v", filename, err)
+		}
+		totalLoaded += loaded
+	}
+
+	log.Printf("Successfully loaded %d NPI records from %d files", totalLoaded, len(files))
+	return nil
+}
+
+func (ls *LoaderService) importNPPESFile(filename string) (int, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open NPPES file: %w", err)
+	}
+	defer file.Close()
+
+	return ls.ImportNPPES(file)
+}
+
+func (ls *LoaderService) ImportNPPES(r io.Reader) (int, error) {
+	reader, err := nppes.NewReader(r)
+	if err != nil {
+		return 0, err
+	}
+
+	var batch []models.NPIRecord
+	totalLoaded := 0
+	skipped := 0
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.Is(err, nppes.ErrInvalidRecord) || errors.As(err, &parseErr) {
+			log.Printf("Skipping NPPES record: %v", err)
+			skipped++
+			continue
+		}
+		if err != nil {
+			return totalLoaded, fmt.Errorf("failed to read NPPES record: %w", err)
+		}
+
+		batch = append(batch, *record)
+		if len(batch) >= ls.batchSize {
+			if err := ls.repo.BatchUpsertNPIRecords(batch); err != nil {
+				return totalLoaded, fmt.Errorf("failed to batch upsert NPI records: %w", err)
+			}
+			totalLoaded += len(batch)
+			batch = batch[:0]
+		}
+	}
+
+	if len(batch) > 0 {
+		if err := ls.repo.BatchUpsertNPIRecords(batch); err != nil {
+			return totalLoaded, fmt.Errorf("failed to batch upsert NPI records: %w", err)
+		}
+		totalLoaded += len(batch)
+	}
+
+	ls.logger.LogEvent("nppes_imported", map[string]interface{}{
+		"records": totalLoaded,
+		"skipped": skipped,
+	})
+
+	return totalLoaded, nil
+}
+
 func (ls *LoaderService) loadPharmaciesFromCSV(filename string) (int, error) {
 	file, err := os.Open(filename)
 	if err != nil {
@@ -136,6 +235,11 @@ func (ls *LoaderService) loadPharmaciesFromCSV(filename string) (int, error) {
 		}
 
 		if err := ls.validator.ValidateNPI(pharmacy.NPI); err != nil {
+			log.Printf("Skipping pharmacy at line %d in %s: %v", lineNumber, filename, err)
+			continue
+		}
+
+		if err := ls.validator.ValidateChain(pharmacy.Chain); err != nil {
 			log.Printf("%v", err)
 			continue
 		}
@@ -293,13 +397,47 @@ func (ls *LoaderService) LoadClaimsFromData(dataDir string) error {
 		"claims",
 		"*.json",
 		ls.repo.CountClaims,
-		loadJSONFromFile[models.Claim],
+		ls.loadClaimsFile,
 		ls.processClaimsBatch,
 		"claims",
 	)
 }
 
+func (ls *LoaderService) loadClaimsFile(filename string) ([]models.Claim, error) {
+	claims, err := loadJSONFromFile[models.Claim](filename)
+	if err != nil {
+		return nil, err
+	}
+
+	valid := make([]models.Claim, 0, len(claims))
+	for _, claim := range claims {
+		claim.NDC = ndc.Normalize(claim.NDC)
+		if err := ls.validator.ValidateNDC(claim.NDC); err != nil {
+			log.Printf("Skipping claim %s in %s: %v", claim.ID, filename, err)
+			continue
+		}
+		if claim.ServiceDate.IsZero() {
+			claim.ServiceDate = parseServiceDate("", claim.Timestamp.Time)
+		}
+		if err := ls.validator.ValidatePrescription(claim); err != nil {
+			log.Printf("Skipping claim %s in %s: %v", claim.ID, filename, err)
+			continue
+		}
+		valid = append(valid, claim)
+	}
+	return valid, nil
+}
+
 func (ls *LoaderService) processClaimsBatch(claims []models.Claim) error {
+	for i := range claims {
+		if claims[i].Pricing.Basis == "" {
+			claims[i].Pricing = SubmittedPricing(claims[i].Price)
+		}
+		if claims[i].CostShare == (models.CostShare{}) {
+			claims[i].CostShare.PlanPaid = claims[i].Pricing.Total
+		}
+	}
+
 	if err := ls.repo.BatchCreateClaims(claims); err != nil {
 		return fmt.Errorf("failed to batch create claims: %w", err)
 	}
@@ -331,14 +469,473 @@ func (ls *LoaderService) LoadReversalsFromData(dataDir string) error {
 }
 
 func (ls *LoaderService) processReversalsBatch(reversals []models.Reversal) error {
-	if err := ls.repo.BatchCreateReversals(reversals); err != nil {
+	valid := make([]models.Reversal, 0, len(reversals))
+	for _, reversal := range reversals {
+		if reversal.ReasonCode != "" {
+			if err := ls.validator.ValidateReversalReasonCode(reversal.ReasonCode); err != nil {
+				log.Printf("Skipping reversal %s: %v", reversal.ID, err)
+				continue
+			}
+		}
+		if reversal.Source == "" {
+			reversal.Source = models.ReversalSourceLoader
+		}
+		valid = append(valid, reversal)
+	}
+
+	if err := ls.repo.BatchCreateReversals(valid); err != nil {
 		return fmt.Errorf("failed to batch create reversals: %w", err)
 	}
 
-	for _, reversal := range reversals {
+	for _, reversal := range valid {
 		ls.logger.LogEvent("reversal_loaded", map[string]interface{}{
-			"id":       reversal.ID,
-			"claim_id": reversal.ClaimID,
+			"id":          reversal.ID,
+			"claim_id":    reversal.ClaimID,
+			"reason":      reversal.Reason,
+			"reason_code": reversal.ReasonCode,
+		})
+	}
+
+	return nil
+}
+
+func (ls *LoaderService) LoadDrugPricesFromData(dataDir string) error {
+	return loadDataFromFiles(
+		ls,
+		dataDir,
+		"drug_prices",
+		"*.csv",
+		ls.repo.CountDrugPrices,
+		func(filename string) ([]models.DrugPrice, error) {
+			return loadCSVFromFile(filename, ls.parseDrugPrice)
+		},
+		ls.processDrugPricesBatch,
+		"drug prices",
+	)
+}
+
+func loadCSVFromFile[T any](filename string, parseRecord func([]string) (*T, error)) ([]T, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CSV file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+
+	if _, err := reader.Read(); err != nil {
+		return nil, fmt.Errorf("failed to read header: %w", err)
+	}
+
+	var items []T
+	lineNumber := 1
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		lineNumber++
+		if err != nil {
+			log.Printf("Error reading line %d in %s: %v", lineNumber, filename, err)
+			continue
+		}
+
+		item, err := parseRecord(record)
+		if err != nil {
+			log.Printf("Skipping record at line %d in %s: %v", lineNumber, filename, err)
+			continue
+		}
+
+		items = append(items, *item)
+	}
+
+	return items, nil
+}
+
+func (ls *LoaderService) parseDrugPrice(record []string) (*models.DrugPrice, error) {
+	if len(record) < 6 {
+		return nil, fmt.Errorf("expected 6 columns, got %d", len(record))
+	}
+
+	price := &models.DrugPrice{NDC: ndc.Normalize(record[0])}
+	if err := ls.validator.ValidateNDC(price.NDC); err != nil {
+		return nil, err
+	}
+
+	costs := []struct {
+		name   string
+		value  string
+		target **float64
+	}{
+		{models.PriceBasisAWP, record[1], &price.AWP},
+		{models.PriceBasisWAC, record[2], &price.WAC},
+		{models.PriceBasisMAC, record[3], &price.MAC},
+		{models.PriceBasisNADAC, record[4], &price.NADAC},
+	}
+
+	found := false
+	for _, cost := range costs {
+		value := strings.TrimSpace(cost.value)
+		if value == "" {
+			continue
+		}
+		unitCost, err := strconv.ParseFloat(value, 64)
+		if err != nil || unitCost < 0 {
+			return nil, fmt.Errorf("invalid %s unit cost: %s", cost.name, value)
+		}
+		*cost.target = &unitCost
+		found = true
+	}
+	if !found {
+		return nil, fmt.Errorf("no unit costs for ndc %s", price.NDC)
+	}
+
+	effectiveDate, err := time.Parse(utility.ServiceDateLayout, strings.TrimSpace(record[5]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid effective_date: %s", record[5])
+	}
+	price.EffectiveDate = effectiveDate
+
+	return price, nil
+}
+
+func (ls *LoaderService) processDrugPricesBatch(prices []models.DrugPrice) error {
+	if err := ls.repo.BatchCreateDrugPrices(prices); err != nil {
+		return fmt.Errorf("failed to batch create drug prices: %w", err)
+	}
+
+	for _, price := range prices {
+		ls.logger.LogEvent("drug_price_loaded", map[string]interface{}{
+			"ndc":            price.NDC,
+			"awp":            price.AWP,
+			"wac":            price.WAC,
+			"mac":            price.MAC,
+			"nadac":          price.NADAC,
+			"effective_date": price.EffectiveDate.Format(utility.ServiceDateLayout),
+		})
+	}
+
+	return nil
+}
+
+func (ls *LoaderService) LoadMembersFromData(dataDir string) error {
+	return loadDataFromFiles(
+		ls,
+		dataDir,
+		"members",
+		"*.csv",
+		ls.repo.CountMembers,
+		func(filename string) ([]models.Member, error) {
+			return loadCSVFromFile(filename, ls.parseMember)
+		},
+		ls.processMembersBatch,
+		"members",
+	)
+}
+
+func (ls *LoaderService) parseMember(record []string) (*models.Member, error) {
+	if len(record) < 5 {
+		return nil, fmt.Errorf("expected 5 columns, got %d", len(record))
+	}
+
+	member := &models.Member{
+		MemberID: strings.TrimSpace(record[0]),
+		GroupID:  strings.TrimSpace(record[1]),
+		PlanID:   strings.TrimSpace(record[2]),
+	}
+
+	if err := ls.validator.ValidateMemberID(member.MemberID); err != nil {
+		return nil, err
+	}
+	if member.GroupID == "" || member.PlanID == "" {
+		return nil, fmt.Errorf("group_id and plan_id are required for member %s", member.MemberID)
+	}
+
+	effectiveDate, err := time.Parse(utility.ServiceDateLayout, strings.TrimSpace(record[3]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid effective_date: %s", record[3])
+	}
+	member.EffectiveDate = effectiveDate
+
+	if value := strings.TrimSpace(record[4]); value != "" {
+		terminationDate, err := time.Parse(utility.ServiceDateLayout, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid termination_date: %s", value)
+		}
+		if terminationDate.Before(effectiveDate) {
+			return nil, fmt.Errorf("termination_date %s is before effective_date for member %s", value, member.MemberID)
+		}
+		member.TerminationDate = &terminationDate
+	}
+
+	return member, nil
+}
+
+func (ls *LoaderService) processMembersBatch(members []models.Member) error {
+	if err := ls.repo.BatchCreateMembers(members); err != nil {
+		return fmt.Errorf("failed to batch create members: %w", err)
+	}
+
+	for _, member := range members {
+		ls.logger.LogEvent("member_loaded", map[string]interface{}{
+			"member_id": member.MemberID,
+			"group_id":  member.GroupID,
+			"plan_id":   member.PlanID,
+		})
+	}
+
+	return nil
+}
+
+func (ls *LoaderService) LoadFormularyFromData(dataDir string) error {
+	return loadDataFromFiles(
+		ls,
+		dataDir,
+		"formulary",
+		"*",
+		ls.repo.CountFormularyEntries,
+		ls.loadFormularyFile,
+		ls.processFormularyBatch,
+		"formulary entries",
+	)
+}
+
+func (ls *LoaderService) loadFormularyFile(filename string) ([]models.FormularyEntry, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return loadCSVFromFile(filename, ls.parseFormularyEntry)
+	case ".json":
+		entries, err := loadJSONFromFile[models.FormularyEntry](filename)
+		if err != nil {
+			return nil, err
+		}
+		valid := make([]models.FormularyEntry, 0, len(entries))
+		for _, entry := range entries {
+			entry.NDC = ndc.Normalize(entry.NDC)
+			if err := ls.validator.ValidateFormularyEntry(entry); err != nil {
+				log.Printf("Skipping formulary entry %s/%s in %s: %v", entry.PlanID, entry.NDC, filename, err)
+				continue
+			}
+			valid = append(valid, entry)
+		}
+		return valid, nil
+	default:
+		return nil, fmt.Errorf("unsupported formulary file format: %s", filepath.Base(filename))
+	}
+}
+
+func (ls *LoaderService) parseFormularyEntry(record []string) (*models.FormularyEntry, error) {
+	if len(record) < 6 {
+		return nil, fmt.Errorf("expected 6 columns, got %d", len(record))
+	}
+
+	entry := &models.FormularyEntry{
+		PlanID: strings.TrimSpace(record[0]),
+		NDC:    ndc.Normalize(record[1]),
+	}
+
+	tier, err := strconv.Atoi(strings.TrimSpace(record[2]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid tier: %s", record[2])
+	}
+	entry.Tier = tier
+
+	if value := strings.TrimSpace(record[3]); value != "" {
+		limit, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity_limit: %s", value)
+		}
+		entry.QuantityLimit = &limit
+	}
+
+	flags := []struct {
+		name   string
+		value  string
+		target *bool
+	}{
+		{"prior_auth_required", record[4], &entry.PriorAuthRequired},
+		{"step_therapy_required", record[5], &entry.StepTherapyRequired},
+	}
+	for _, flag := range flags {
+		value := strings.TrimSpace(flag.value)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %s", flag.name, value)
+		}
+		*flag.target = parsed
+	}
+
+	if err := ls.validator.ValidateFormularyEntry(*entry); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (ls *LoaderService) processFormularyBatch(entries []models.FormularyEntry) error {
+	if err := ls.repo.BatchCreateFormularyEntries(entries); err != nil {
+		return fmt.Errorf("failed to batch create formulary entries: %w", err)
+	}
+
+	for _, entry := range entries {
+		ls.logger.LogEvent("formulary_entry_loaded", map[string]interface{}{
+			"plan_id":               entry.PlanID,
+			"ndc":                   entry.NDC,
+			"tier":                  entry.Tier,
+			"quantity_limit":        entry.QuantityLimit,
+			"prior_auth_required":   entry.PriorAuthRequired,
+			"step_therapy_required": entry.StepTherapyRequired,
+		})
+	}
+
+	return nil
+}
+
+func (ls *LoaderService) LoadBenefitPlansFromData(dataDir string) error {
+	return loadDataFromFiles(
+		ls,
+		dataDir,
+		"plans",
+		"*.json",
+		ls.repo.CountBenefitPlans,
+		ls.loadBenefitPlansFile,
+		ls.processBenefitPlansBatch,
+		"benefit plans",
+	)
+}
+
+func (ls *LoaderService) loadBenefitPlansFile(filename string) ([]models.BenefitPlan, error) {
+	plans, err := loadJSONFromFile[models.BenefitPlan](filename)
+	if err != nil {
+		return nil, err
+	}
+
+	valid := make([]models.BenefitPlan, 0, len(plans))
+	for _, plan := range plans {
+		if err := ls.validator.ValidateBenefitPlan(plan); err != nil {
+			log.Printf("Skipping benefit plan %s in %s: %v", plan.PlanID, filename, err)
+			continue
+		}
+		valid = append(valid, plan)
+	}
+	return valid, nil
+}
+
+func (ls *LoaderService) processBenefitPlansBatch(plans []models.BenefitPlan) error {
+	if err := ls.repo.BatchCreateBenefitPlans(plans); err != nil {
+		return fmt.Errorf("failed to batch create benefit plans: %w", err)
+	}
+
+	for _, plan := range plans {
+		ls.logger.LogEvent("benefit_plan_loaded", map[string]interface{}{
+			"plan_id":    plan.PlanID,
+			"deductible": plan.Deductible,
+			"oop_max":    plan.OOPMax,
+			"tiers":      len(plan.Tiers),
+		})
+	}
+
+	return nil
+}
+
+func (ls *LoaderService) LoadDrugClassesFromData(dataDir string) error {
+	return loadDataFromFiles(
+		ls,
+		dataDir,
+		"drug_classes",
+		"*.csv",
+		ls.repo.CountDrugClasses,
+		func(filename string) ([]models.DrugClass, error) {
+			return loadCSVFromFile(filename, ls.parseDrugClass)
+		},
+		ls.processDrugClassesBatch,
+		"drug classes",
+	)
+}
+
+func (ls *LoaderService) parseDrugClass(record []string) (*models.DrugClass, error) {
+	if len(record) < 2 {
+		return nil, fmt.Errorf("expected 2 columns, got %d", len(record))
+	}
+
+	drugClass := &models.DrugClass{
+		NDC:       ndc.Normalize(record[0]),
+		DrugClass: strings.ToLower(strings.TrimSpace(record[1])),
+	}
+
+	if err := ls.validator.ValidateDrugClass(*drugClass); err != nil {
+		return nil, err
+	}
+
+	return drugClass, nil
+}
+
+func (ls *LoaderService) processDrugClassesBatch(classes []models.DrugClass) error {
+	if err := ls.repo.BatchCreateDrugClasses(classes); err != nil {
+		return fmt.Errorf("failed to batch create drug classes: %w", err)
+	}
+
+	for _, drugClass := range classes {
+		ls.logger.LogEvent("drug_class_loaded", map[string]interface{}{
+			"ndc":        drugClass.NDC,
+			"drug_class": drugClass.DrugClass,
+		})
+	}
+
+	return nil
+}
+
+func (ls *LoaderService) LoadDrugInteractionsFromData(dataDir string) error {
+	return loadDataFromFiles(
+		ls,
+		dataDir,
+		"drug_interactions",
+		"*.csv",
+		ls.repo.CountDrugInteractions,
+		func(filename string) ([]models.DrugInteraction, error) {
+			return loadCSVFromFile(filename, ls.parseDrugInteraction)
+		},
+		ls.processDrugInteractionsBatch,
+		"drug interactions",
+	)
+}
+
+func (ls *LoaderService) parseDrugInteraction(record []string) (*models.DrugInteraction, error) {
+	if len(record) < 4 {
+		return nil, fmt.Errorf("expected 4 columns, got %d", len(record))
+	}
+
+	interaction := &models.DrugInteraction{
+		ClassA:      strings.ToLower(strings.TrimSpace(record[0])),
+		ClassB:      strings.ToLower(strings.TrimSpace(record[1])),
+		Severity:    strings.ToLower(strings.TrimSpace(record[2])),
+		Description: strings.TrimSpace(record[3]),
+	}
+	if interaction.ClassB < interaction.ClassA {
+		interaction.ClassA, interaction.ClassB = interaction.ClassB, interaction.ClassA
+	}
+
+	if err := ls.validator.ValidateDrugInteraction(*interaction); err != nil {
+		return nil, err
+	}
+
+	return interaction, nil
+}
+
+func (ls *LoaderService) processDrugInteractionsBatch(interactions []models.DrugInteraction) error {
+	if err := ls.repo.BatchCreateDrugInteractions(interactions); err != nil {
+		return fmt.Errorf("failed to batch create drug interactions: %w", err)
+	}
+
+	for _, interaction := range interactions {
+		ls.logger.LogEvent("drug_interaction_loaded", map[string]interface{}{
+			"class_a":  interaction.ClassA,
+			"class_b":  interaction.ClassB,
+			"severity": interaction.Severity,
 		})
 	}
 
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"fmt"
+
+	"pharmacyclaims/internal/core"
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/nppes"
+	"pharmacyclaims/internal/repository"
+	"pharmacyclaims/internal/utility"
+)
+
+type PharmacyService struct {
+	repo      *repository.Postgres
+	logger    *core.Logger
+	validator *utility.Validator
+}
+
+func NewPharmacyService(repo *repository.Postgres, logger *core.Logger) *PharmacyService {
+	return &PharmacyService{
+		repo:      repo,
+		logger:    logger,
+		validator: utility.NewValidator(),
+	}
+}
+
+func (ps *PharmacyService) ValidatePharmacy(request models.PharmacyRequest) error {
+	return ps.validator.ValidatePharmacyRequest(request)
+}
+
+func (ps *PharmacyService) ListPharmacies(filter models.PharmacyFilter) ([]models.Pharmacy, error) {
+	if filter.Chain != "" {
+		if err := ps.validator.ValidateChain(filter.Chain); err != nil {
+			return nil, err
+		}
+	}
+
+	pharmacies, err := ps.repo.ListPharmacies(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pharmacies: %w", err)
+	}
+
+	return pharmacies, nil
+}
+
+func (ps *PharmacyService) GetPharmacy(npi string) (*models.Pharmacy, error) {
+	return ps.repo.GetPharmacyByNPI(npi)
+}
+
+func (ps *PharmacyService) CreatePharmacy(request models.PharmacyRequest) (*models.Pharmacy, error) {
+	if err := ps.ValidatePharmacy(request); err != nil {
+		return nil, err
+	}
+
+	if err := ps.validator.ValidateNPICheckDigit(request.NPI); err != nil {
+		return nil, err
+	}
+
+	if err := ps.verifyNPI(request.NPI); err != nil {
+		return nil, err
+	}
+
+	pharmacy := &models.Pharmacy{
+		NPI:    request.NPI,
+		Chain:  request.Chain,
+		Active: true,
+	}
+	if request.Active != nil {
+		pharmacy.Active = *request.Active
+	}
+
+	if err := ps.repo.CreatePharmacy(pharmacy); err != nil {
+		return nil, err
+	}
+
+	ps.logger.LogEvent("pharmacy_created", map[string]interface{}{
+		"npi":    pharmacy.NPI,
+		"chain":  pharmacy.Chain,
+		"active": pharmacy.Active,
+	})
+
+	return pharmacy, nil
+}
+
+func (ps *PharmacyService) verifyNPI(npi string) error {
+	record, err := ps.repo.GetNPIRecord(npi)
+	if err != nil {
+		return err
+	}
+
+	if record.DeactivationDate != nil {
+		return fmt.Errorf("%w: npi %s was deactivated %s", models.ErrNPINotRegistered, npi, record.DeactivationDate.Format(utility.ServiceDateLayout))
+	}
+	if record.EntityType != models.NPIEntityTypeOrganization {
+		return fmt.Errorf("%w: npi %s is not an organization", models.ErrNPINotPharmacy, npi)
+	}
+	for _, code := range record.TaxonomyCodes {
+		if nppes.IsPharmacyTaxonomy(code) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: npi %s has no pharmacy taxonomy code", models.ErrNPINotPharmacy, npi)
+}
+
+func (ps *PharmacyService) UpdatePharmacy(npi string, request models.PharmacyRequest) (*models.Pharmacy, error) {
+	request.NPI = npi
+	if err := ps.ValidatePharmacy(request); err != nil {
+		return nil, err
+	}
+
+	pharmacy, err := ps.GetPharmacy(npi)
+	if err != nil {
+		return nil, err
+	}
+
+	pharmacy.Chain = request.Chain
+	if request.Active != nil {
+		pharmacy.Active = *request.Active
+	}
+
+	if err := ps.repo.UpdatePharmacy(pharmacy); err != nil {
+		return nil, err
+	}
+
+	ps.logger.LogEvent("pharmacy_updated", map[string]interface{}{
+		"npi":    pharmacy.NPI,
+		"chain":  pharmacy.Chain,
+		"active": pharmacy.Active,
+	})
+
+	return pharmacy, nil
+}
+
+func (ps *PharmacyService) DeactivatePharmacy(npi string) (*models.Pharmacy, error) {
+	pharmacy, err := ps.GetPharmacy(npi)
+	if err != nil {
+		return nil, err
+	}
+
+	pharmacy.Active = false
+	if err := ps.repo.UpdatePharmacy(pharmacy); err != nil {
+		return nil, err
+	}
+
+	ps.logger.LogEvent("pharmacy_deactivated", map[string]interface{}{
+		"npi":   pharmacy.NPI,
+		"chain": pharmacy.Chain,
+	})
+
+	return pharmacy, nil
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"errors"
+	"math"
+
+	"pharmacyclaims/internal/models"
+)
+
+const (
+	DefaultPricingBasis   = models.PriceBasisAWP
+	DefaultPricingPercent = -15.0
+	DefaultDispensingFee  = 2.00
+)
+
+type PricingFormula struct {
+	Basis         string
+	Percent       float64
+	DispensingFee float64
+}
+
+func DefaultPricingFormula() PricingFormula {
+	return PricingFormula{
+		Basis:         DefaultPricingBasis,
+		Percent:       DefaultPricingPercent,
+		DispensingFee: DefaultDispensingFee,
+	}
+}
+
+func contractFormula(contract *models.Contract) PricingFormula {
+	return PricingFormula{
+		Basis:         contract.Basis,
+		Percent:       contract.Percent,
+		DispensingFee: contract.DispensingFee,
+	}
+}
+
+func (cs *ClaimsService) priceClaim(claim *models.Claim, pharmacy *models.Pharmacy) error {
+	formula := cs.policy.PricingFormula
+	var contractID *int
+
+	contract, err := cs.repo.GetContractForChain(pharmacy.Chain, claim.ServiceDate)
+	if err == nil {
+		formula = contractFormula(contract)
+		contractID = &contract.ID
+	} else if !errors.Is(err, models.ErrContractNotFound) {
+		return err
+	}
+
+	price, err := cs.repo.GetDrugPrice(claim.NDC, claim.ServiceDate)
+	if errors.Is(err, models.ErrDrugPriceNotFound) {
+		price = nil
+	} else if err != nil {
+		return err
+	}
+
+	claim.Pricing = PriceClaim(formula, price, claim.Quantity, claim.Price)
+	claim.Pricing.ContractID = contractID
+	return nil
+}
+
+func PriceClaim(formula PricingFormula, price *models.DrugPrice, quantity, submitted float64) models.ClaimPricing {
+	submittedPricing := SubmittedPricing(submitted)
+	if price == nil {
+		return submittedPricing
+	}
+
+	basis := formula.Basis
+	var ingredientCost float64
+	found := false
+
+	if unitCost := drugUnitCost(price, formula.Basis); unitCost != nil {
+		ingredientCost = *unitCost * quantity * (1 + formula.Percent/100)
+		found = true
+	}
+	if price.MAC != nil && formula.Basis != models.PriceBasisMAC {
+		macCost := *price.MAC * quantity
+		if !found || macCost < ingredientCost {
+			basis = models.PriceBasisMAC
+			ingredientCost = macCost
+			found = true
+		}
+	}
+	if !found {
+		return submittedPricing
+	}
+
+	pricing := models.ClaimPricing{
+		Basis:          basis,
+		IngredientCost: roundCents(ingredientCost),
+		DispensingFee:  roundCents(formula.DispensingFee),
+	}
+	pricing.Total = roundCents(pricing.IngredientCost + pricing.DispensingFee)
+
+	if submittedPricing.Total <= pricing.Total {
+		return submittedPricing
+	}
+	return pricing
+}
+
+func SubmittedPricing(submitted float64) models.ClaimPricing {
+	amount := roundCents(submitted)
+	return models.ClaimPricing{
+		Basis:          models.PriceBasisSubmitted,
+		IngredientCost: amount,
+		Total:          amount,
+	}
+}
+
+func drugUnitCost(price *models.DrugPrice, basis string) *float64 {
+	switch basis {
+	case models.PriceBasisAWP:
+		return price.AWP
+	case models.PriceBasisWAC:
+		return price.WAC
+	case models.PriceBasisMAC:
+		return price.MAC
+	case models.PriceBasisNADAC:
+		return price.NADAC
+	}
+	return nil
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"strings"
+	"time"
+
+	"pharmacyclaims/internal/core"
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/repository"
+	"pharmacyclaims/internal/utility"
+)
+
+type PriorAuthService struct {
+	repo      *repository.Postgres
+	logger    *core.Logger
+	validator *utility.Validator
+}
+
+func NewPriorAuthService(repo *repository.Postgres, logger *core.Logger) *PriorAuthService {
+	return &PriorAuthService{
+		repo:      repo,
+		logger:    logger,
+		validator: utility.NewValidator(),
+	}
+}
+
+func (ps *PriorAuthService) ValidatePriorAuth(request models.PriorAuthRequest) error {
+	return ps.validator.ValidatePriorAuthRequest(request)
+}
+
+func (ps *PriorAuthService) GetPriorAuth(id int) (*models.PriorAuth, error) {
+	return ps.repo.GetPriorAuth(id)
+}
+
+func (ps *PriorAuthService) CreatePriorAuth(request models.PriorAuthRequest) (*models.PriorAuth, error) {
+	if err := ps.ValidatePriorAuth(request); err != nil {
+		return nil, err
+	}
+
+	if _, err := ps.repo.GetMember(request.MemberID); err != nil {
+		return nil, err
+	}
+
+	priorAuth := &models.PriorAuth{
+		MemberID:         request.MemberID,
+		NDC:              request.NDC,
+		DrugClass:        strings.ToLower(request.DrugClass),
+		ApprovedQuantity: request.ApprovedQuantity,
+		Status:           request.Status,
+	}
+	priorAuth.EffectiveFrom, _ = time.Parse(utility.ServiceDateLayout, request.EffectiveFrom)
+	priorAuth.EffectiveTo, _ = time.Parse(utility.ServiceDateLayout, request.EffectiveTo)
+	if priorAuth.Status == "" {
+		priorAuth.Status = models.PriorAuthStatusApproved
+	}
+
+	if err := ps.repo.CreatePriorAuth(priorAuth); err != nil {
+		return nil, err
+	}
+
+	ps.logger.LogEvent("prior_auth_created", map[string]interface{}{
+		"prior_auth_id":     priorAuth.ID,
+		"member_id":         priorAuth.MemberID,
+		"ndc":               priorAuth.NDC,
+		"drug_class":        priorAuth.DrugClass,
+		"approved_quantity": priorAuth.ApprovedQuantity,
+		"effective_from":    priorAuth.EffectiveFrom.Format(utility.ServiceDateLayout),
+		"effective_to":      priorAuth.EffectiveTo.Format(utility.ServiceDateLayout),
+		"status":            priorAuth.Status,
+	})
+
+	return priorAuth, nil
+}
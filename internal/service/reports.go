@@ -0,0 +1,142 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/repository"
+	"pharmacyclaims/internal/utility"
+)
+
+const (
+	DefaultChainRecommendationLimit = 2
+	MaxChainRecommendationLimit     = 10
+	DefaultQuantityReportLimit      = 5
+	MaxQuantityReportLimit          = 50
+)
+
+type ReportsService struct {
+	repo      *repository.Postgres
+	validator *utility.Validator
+}
+
+func NewReportsService(repo *repository.Postgres) *ReportsService {
+	return &ReportsService{
+		repo:      repo,
+		validator: utility.NewValidator(),
+	}
+}
+
+func (rs *ReportsService) ValidateMetricsFilter(filter models.MetricsFilter) error {
+	if filter.NPI != "" {
+		if err := rs.validator.ValidateNPI(filter.NPI); err != nil {
+			return err
+		}
+	}
+	if filter.NDC != "" {
+		if err := rs.validator.ValidateNDC(filter.NDC); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rs *ReportsService) GetClaimMetrics(filter models.MetricsFilter) ([]models.ClaimMetrics, error) {
+	if err := rs.ValidateMetricsFilter(filter); err != nil {
+		return nil, err
+	}
+
+	metrics, err := rs.repo.GetClaimMetrics(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claim metrics: %w", err)
+	}
+
+	return metrics, nil
+}
+
+func (rs *ReportsService) ValidateRecommendationFilter(filter models.RecommendationFilter) error {
+	for _, ndc := range filter.NDCs {
+		if err := rs.validator.ValidateNDC(ndc); err != nil {
+			return err
+		}
+	}
+	if filter.Limit < 0 || filter.Limit > MaxChainRecommendationLimit {
+		return models.NewValidationError("limit", fmt.Sprintf("invalid limit: must be between 1 and %d", MaxChainRecommendationLimit))
+	}
+	return nil
+}
+
+func (rs *ReportsService) GetChainRecommendations(filter models.RecommendationFilter) ([]models.ChainRecommendation, error) {
+	if err := rs.ValidateRecommendationFilter(filter); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = DefaultChainRecommendationLimit
+	}
+
+	recommendations, err := rs.repo.GetChainRecommendations(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain recommendations: %w", err)
+	}
+
+	return recommendations, nil
+}
+
+func (rs *ReportsService) ValidateQuantityFilter(filter models.QuantityFilter) error {
+	for _, ndc := range filter.NDCs {
+		if err := rs.validator.ValidateNDC(ndc); err != nil {
+			return err
+		}
+	}
+	if filter.Limit < 0 || filter.Limit > MaxQuantityReportLimit {
+		return models.NewValidationError("limit", fmt.Sprintf("invalid limit: must be between 1 and %d", MaxQuantityReportLimit))
+	}
+	return nil
+}
+
+func (rs *ReportsService) GetMostPrescribedQuantities(filter models.QuantityFilter) ([]models.QuantityReport, error) {
+	if err := rs.ValidateQuantityFilter(filter); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = DefaultQuantityReportLimit
+	}
+
+	reports, err := rs.repo.GetMostPrescribedQuantities(filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prescribed quantities: %w", err)
+	}
+
+	return reports, nil
+}
+
+func (rs *ReportsService) ExportClaimMetrics(filter models.MetricsFilter, w io.Writer) error {
+	metrics, err := rs.GetClaimMetrics(filter)
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, metrics)
+}
+
+func (rs *ReportsService) ExportMostPrescribedQuantities(filter models.QuantityFilter, w io.Writer) error {
+	reports, err := rs.GetMostPrescribedQuantities(filter)
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(w, reports)
+}
+
+func writeJSON(w io.Writer, data interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+	return nil
+}
@@ -2,9 +2,62 @@ package utility
 
 import (
 	"fmt"
-	"strconv"
+	"strings"
+	"time"
 
 	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/ndc"
+	"pharmacyclaims/internal/nppes"
+)
+
+var ValidChains = []string{"health", "saint", "doctor"}
+
+var ValidPriceBases = []string{
+	models.PriceBasisAWP,
+	models.PriceBasisWAC,
+	models.PriceBasisMAC,
+	models.PriceBasisNADAC,
+}
+
+var ValidDURSeverities = []string{
+	models.DURSeverityMajor,
+	models.DURSeverityModerate,
+	models.DURSeverityMinor,
+}
+
+var ValidPriorAuthStatuses = []string{
+	models.PriorAuthStatusPending,
+	models.PriorAuthStatusApproved,
+	models.PriorAuthStatusDenied,
+}
+
+var ValidReversalReasonCodes = []string{
+	"billing_error",
+	"not_picked_up",
+	"duplicate_claim",
+	"wrong_drug",
+	"wrong_quantity",
+	"wrong_patient",
+	"other",
+}
+
+const (
+	MaxReversalReasonLength     = 500
+	MaxRequestedByLength        = 100
+	MaxIdempotencyKeyLength     = 255
+	MaxNDCLength                = ndc.Length
+	MaxMemberIDLength           = 20
+	MaxPlanIDLength             = 20
+	MinFormularyTier            = 1
+	MaxFormularyTier            = 5
+	MaxDrugClassLength          = 50
+	MaxInteractionLength        = 255
+	MaxDaysSupply               = 365
+	MaxFillNumber               = 99
+	MaxPrescriptionNumberDigits = 12
+	MaxPrescriptionAgeDays      = 365
+
+	ServiceDateLayout = "2006-01-02"
 )
 
 type Validator struct{}
@@ -14,8 +67,11 @@ func NewValidator() *Validator {
 }
 
 func (v *Validator) ValidateClaimRequest(request models.ClaimRequest) error {
-	if err := v.ValidateNDC(request.NDC); err != nil {
-		return err
+	if request.NDC == "" {
+		return models.NewValidationError("ndc", "ndc is required")
+	}
+	if len(request.NDC) > MaxNDCLength {
+		return models.NewValidationError("ndc", fmt.Sprintf("invalid NDC: must be at most %d characters", MaxNDCLength))
 	}
 
 	if err := v.ValidateNPI(request.NPI); err != nil {
@@ -30,39 +86,531 @@ func (v *Validator) ValidateClaimRequest(request models.ClaimRequest) error {
 		return err
 	}
 
+	if err := v.ValidateMemberID(request.MemberID); err != nil {
+		return err
+	}
+
+	if err := v.ValidateDaysSupply(request.DaysSupply); err != nil {
+		return err
+	}
+
+	if request.DAWCode != "" {
+		if err := v.ValidateDAWCode(request.DAWCode); err != nil {
+			return err
+		}
+	}
+
+	if err := v.ValidateFillNumber(request.FillNumber); err != nil {
+		return err
+	}
+
+	if request.PrescriptionNumber != "" {
+		if err := v.ValidatePrescriptionNumber(request.PrescriptionNumber); err != nil {
+			return err
+		}
+	}
+
+	if request.PrescriberNPI != "" {
+		if err := v.ValidatePrescriberNPI(request.PrescriberNPI); err != nil {
+			return err
+		}
+	}
+
+	serviceDate := time.Now().Format(ServiceDateLayout)
+	if request.ServiceDate != "" {
+		if err := v.ValidateServiceDate(request.ServiceDate); err != nil {
+			return err
+		}
+		serviceDate = request.ServiceDate
+	}
+
+	if request.DateWritten != "" {
+		dateWritten, err := time.Parse(ServiceDateLayout, request.DateWritten)
+		if err != nil {
+			return models.NewValidationError("date_written", "invalid date_written: must be a date (YYYY-MM-DD)")
+		}
+		filled, _ := time.Parse(ServiceDateLayout, serviceDate)
+		if err := v.ValidateDateWritten(dateWritten, filled); err != nil {
+			return err
+		}
+	}
+
+	if len(request.IdempotencyKey) > MaxIdempotencyKeyLength {
+		return models.NewValidationError("idempotency_key", fmt.Sprintf("invalid idempotency key: must be at most %d characters", MaxIdempotencyKeyLength))
+	}
+
+	return nil
+}
+
+func (v *Validator) ValidatePharmacyRequest(request models.PharmacyRequest) error {
+	if err := v.ValidateNPI(request.NPI); err != nil {
+		return err
+	}
+
+	if err := v.ValidateChain(request.Chain); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (v *Validator) ValidateReversalRequest(request models.ReversalRequest) error {
+	if err := v.ValidateNPI(request.NPI); err != nil {
+		return err
+	}
+
+	if request.NDC != "" {
+		if err := v.ValidateNDC(request.NDC); err != nil {
+			return err
+		}
+	}
+
+	if request.ServiceDate != "" {
+		if err := v.ValidateServiceDate(request.ServiceDate); err != nil {
+			return err
+		}
+	}
+
+	if request.ReasonCode != "" {
+		if err := v.ValidateReversalReasonCode(request.ReasonCode); err != nil {
+			return err
+		}
+	}
+
+	if len(request.Reason) > MaxReversalReasonLength {
+		return models.NewValidationError("reason", fmt.Sprintf("invalid reason: must be at most %d characters", MaxReversalReasonLength))
+	}
+
+	if len(request.RequestedBy) > MaxRequestedByLength {
+		return models.NewValidationError("requested_by", fmt.Sprintf("invalid requested_by: must be at most %d characters", MaxRequestedByLength))
+	}
+
+	return nil
+}
+
+func (v *Validator) ValidateRebillRequest(request models.RebillRequest) error {
+	if err := v.ValidateClaimRequest(models.ClaimRequest{
+		NDC:                request.NDC,
+		Quantity:           request.Quantity,
+		DaysSupply:         request.DaysSupply,
+		DAWCode:            request.DAWCode,
+		FillNumber:         request.FillNumber,
+		PrescriptionNumber: request.PrescriptionNumber,
+		DateWritten:        request.DateWritten,
+		PrescriberNPI:      request.PrescriberNPI,
+		NPI:                request.NPI,
+		Price:              request.Price,
+		MemberID:           request.MemberID,
+		ServiceDate:        request.ServiceDate,
+	}); err != nil {
+		return err
+	}
+
+	return v.ValidateReversalRequest(models.ReversalRequest{
+		NPI:         request.NPI,
+		Reason:      request.Reason,
+		ReasonCode:  request.ReasonCode,
+		RequestedBy: request.RequestedBy,
+	})
+}
+
+func (v *Validator) ValidateContractRequest(request models.ContractRequest) error {
+	if err := v.ValidateChain(request.Chain); err != nil {
+		return err
+	}
+
+	if err := v.ValidatePriceBasis(request.Basis); err != nil {
+		return err
+	}
+
+	if request.Percent <= -100 {
+		return models.NewValidationError("percent", "invalid percent: must be greater than -100")
+	}
+
+	if request.DispensingFee < 0 {
+		return models.NewValidationError("dispensing_fee", "invalid dispensing_fee: must be non-negative")
+	}
+
+	if request.EffectiveFrom == "" {
+		return models.NewValidationError("effective_from", "effective_from is required")
+	}
+	effectiveFrom, err := time.Parse(ServiceDateLayout, request.EffectiveFrom)
+	if err != nil {
+		return models.NewValidationError("effective_from", "invalid effective_from: must be a date (YYYY-MM-DD)")
+	}
+
+	if request.EffectiveTo != "" {
+		effectiveTo, err := time.Parse(ServiceDateLayout, request.EffectiveTo)
+		if err != nil {
+			return models.NewValidationError("effective_to", "invalid effective_to: must be a date (YYYY-MM-DD)")
+		}
+		if effectiveTo.Before(effectiveFrom) {
+			return models.NewValidationError("effective_to", "invalid effective_to: must not be before effective_from")
+		}
+	}
+
+	if len(request.RequestedBy) > MaxRequestedByLength {
+		return models.NewValidationError("requested_by", fmt.Sprintf("invalid requested_by: must be at most %d characters", MaxRequestedByLength))
+	}
+
+	return nil
+}
+
+func (v *Validator) ValidatePriorAuthRequest(request models.PriorAuthRequest) error {
+	if err := v.ValidateMemberID(request.MemberID); err != nil {
+		return err
+	}
+
+	if (request.NDC == "") == (request.DrugClass == "") {
+		return models.NewValidationError("ndc", "invalid prior authorization: set either ndc or drug_class")
+	}
+	if request.NDC != "" {
+		if err := v.ValidateNDC(request.NDC); err != nil {
+			return err
+		}
+	}
+	if len(request.DrugClass) > MaxDrugClassLength {
+		return models.NewValidationError("drug_class", fmt.Sprintf("invalid drug_class: must be at most %d characters", MaxDrugClassLength))
+	}
+
+	if request.ApprovedQuantity <= 0 {
+		return models.NewValidationError("approved_quantity", "invalid approved_quantity: must be greater than 0")
+	}
+
+	effectiveFrom, err := time.Parse(ServiceDateLayout, request.EffectiveFrom)
+	if err != nil {
+		return models.NewValidationError("effective_from", "invalid effective_from: must be a date (YYYY-MM-DD)")
+	}
+	effectiveTo, err := time.Parse(ServiceDateLayout, request.EffectiveTo)
+	if err != nil {
+		return models.NewValidationError("effective_to", "invalid effective_to: must be a date (YYYY-MM-DD)")
+	}
+	if effectiveTo.Before(effectiveFrom) {
+		return models.NewValidationError("effective_to", "invalid effective_to: must not be before effective_from")
+	}
+
+	if request.Status != "" {
+		if err := v.ValidatePriorAuthStatus(request.Status); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) ValidateFormularyEntry(entry models.FormularyEntry) error {
+	if entry.PlanID == "" {
+		return models.NewValidationError("plan_id", "plan_id is required")
+	}
+	if len(entry.PlanID) > MaxPlanIDLength {
+		return models.NewValidationError("plan_id", fmt.Sprintf("invalid plan_id: must be at most %d characters", MaxPlanIDLength))
+	}
+
+	if err := v.ValidateNDC(entry.NDC); err != nil {
+		return err
+	}
+
+	if entry.Tier < MinFormularyTier || entry.Tier > MaxFormularyTier {
+		return models.NewValidationError("tier", fmt.Sprintf("invalid tier: must be between %d and %d", MinFormularyTier, MaxFormularyTier))
+	}
+
+	if entry.QuantityLimit != nil && *entry.QuantityLimit <= 0 {
+		return models.NewValidationError("quantity_limit", "invalid quantity_limit: must be greater than 0")
+	}
+
+	return nil
+}
+
+func (v *Validator) ValidateBenefitPlan(plan models.BenefitPlan) error {
+	if plan.PlanID == "" {
+		return models.NewValidationError("plan_id", "plan_id is required")
+	}
+	if len(plan.PlanID) > MaxPlanIDLength {
+		return models.NewValidationError("plan_id", fmt.Sprintf("invalid plan_id: must be at most %d characters", MaxPlanIDLength))
+	}
+
+	if plan.Deductible < 0 {
+		return models.NewValidationError("deductible", "invalid deductible: must not be negative")
+	}
+	if plan.OOPMax != nil && *plan.OOPMax < plan.Deductible {
+		return models.NewValidationError("oop_max", "invalid oop_max: must be at least the deductible")
+	}
+
+	seen := make(map[int]bool, len(plan.Tiers))
+	for _, tier := range plan.Tiers {
+		if tier.Tier < MinFormularyTier || tier.Tier > MaxFormularyTier {
+			return models.NewValidationError("tiers", fmt.Sprintf("invalid tier: must be between %d and %d", MinFormularyTier, MaxFormularyTier))
+		}
+		if seen[tier.Tier] {
+			return models.NewValidationError("tiers", fmt.Sprintf("invalid tiers: tier %d is listed more than once", tier.Tier))
+		}
+		seen[tier.Tier] = true
+
+		if tier.Copay < 0 {
+			return models.NewValidationError("copay", "invalid copay: must not be negative")
+		}
+		if tier.CoinsurancePercent < 0 || tier.CoinsurancePercent > 100 {
+			return models.NewValidationError("coinsurance_percent", "invalid coinsurance_percent: must be between 0 and 100")
+		}
+		if tier.Copay > 0 && tier.CoinsurancePercent > 0 {
+			return models.NewValidationError("tiers", fmt.Sprintf("invalid tier %d: set either copay or coinsurance_percent, not both", tier.Tier))
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) ValidateDrugClass(drugClass models.DrugClass) error {
+	if err := v.ValidateNDC(drugClass.NDC); err != nil {
+		return err
+	}
+
+	if drugClass.DrugClass == "" {
+		return models.NewValidationError("drug_class", "drug_class is required")
+	}
+	if len(drugClass.DrugClass) > MaxDrugClassLength {
+		return models.NewValidationError("drug_class", fmt.Sprintf("invalid drug_class: must be at most %d characters", MaxDrugClassLength))
+	}
+
+	return nil
+}
+
+func (v *Validator) ValidateDrugInteraction(interaction models.DrugInteraction) error {
+	for _, class := range []struct{ field, value string }{
+		{"class_a", interaction.ClassA},
+		{"class_b", interaction.ClassB},
+	} {
+		if class.value == "" {
+			return models.NewValidationError(class.field, class.field+" is required")
+		}
+		if len(class.value) > MaxDrugClassLength {
+			return models.NewValidationError(class.field, fmt.Sprintf("invalid %s: must be at most %d characters", class.field, MaxDrugClassLength))
+		}
+	}
+	if interaction.ClassA == interaction.ClassB {
+		return models.NewValidationError("class_b", "invalid class_b: must differ from class_a")
+	}
+
+	if err := v.ValidateDURSeverity(interaction.Severity); err != nil {
+		return err
+	}
+
+	if interaction.Description == "" {
+		return models.NewValidationError("description", "description is required")
+	}
+	if len(interaction.Description) > MaxInteractionLength {
+		return models.NewValidationError("description", fmt.Sprintf("invalid description: must be at most %d characters", MaxInteractionLength))
+	}
+
 	return nil
 }
 
-func (v *Validator) ValidateNDC(ndc string) error {
-	if len(ndc) < 9 || len(ndc) > 11 {
-		return fmt.Errorf("invalid NDC format: must be 9-11 digits")
+func (v *Validator) ValidateDURSeverity(severity string) error {
+	for _, valid := range ValidDURSeverities {
+		if severity == valid {
+			return nil
+		}
 	}
-	if _, err := strconv.Atoi(ndc); err != nil {
-		return fmt.Errorf("invalid NDC format: must be numeric")
+	return models.NewValidationError("severity", fmt.Sprintf("invalid severity: must be one of %s", strings.Join(ValidDURSeverities, ", ")))
+}
+
+func (v *Validator) ValidatePriorAuthStatus(status string) error {
+	for _, valid := range ValidPriorAuthStatuses {
+		if status == valid {
+			return nil
+		}
+	}
+	return models.NewValidationError("status", fmt.Sprintf("invalid status: must be one of %s", strings.Join(ValidPriorAuthStatuses, ", ")))
+}
+
+func (v *Validator) ValidatePriceBasis(basis string) error {
+	for _, valid := range ValidPriceBases {
+		if basis == valid {
+			return nil
+		}
+	}
+	return models.NewValidationError("basis", fmt.Sprintf("invalid basis: must be one of %s", strings.Join(ValidPriceBases, ", ")))
+}
+
+func (v *Validator) ValidateReversalReasonCode(code string) error {
+	for _, valid := range ValidReversalReasonCodes {
+		if code == valid {
+			return nil
+		}
+	}
+	return models.NewValidationError("reason_code", fmt.Sprintf("invalid reason_code: must be one of %s", strings.Join(ValidReversalReasonCodes, ", ")))
+}
+
+func (v *Validator) ValidateNDC(value string) error {
+	code, err := ndc.Parse(value)
+	if err != nil {
+		return models.NewValidationError("ndc", err.Error())
+	}
+	if code.String() != value {
+		return models.NewValidationError("ndc", fmt.Sprintf("invalid NDC format: must be normalized to %d digits", ndc.Length))
 	}
 	return nil
 }
 
 func (v *Validator) ValidateNPI(npi string) error {
-	if len(npi) != 10 {
-		return fmt.Errorf("invalid NPI: must be exactly 10 digits")
+	return validateNPI("npi", "NPI", npi)
+}
+
+func (v *Validator) ValidateNPICheckDigit(npi string) error {
+	if err := v.ValidateNPI(npi); err != nil {
+		return err
 	}
-	if _, err := strconv.Atoi(npi); err != nil {
-		return fmt.Errorf("invalid NPI: must be numeric")
+	if !nppes.ValidNPI(npi) {
+		return models.NewValidationError("npi", "invalid NPI: check digit does not match")
 	}
 	return nil
 }
 
+func (v *Validator) ValidateMemberID(memberID string) error {
+	if memberID == "" {
+		return models.NewValidationError("member_id", "member_id is required")
+	}
+	if len(memberID) > MaxMemberIDLength {
+		return models.NewValidationError("member_id", fmt.Sprintf("invalid member_id: must be at most %d characters", MaxMemberIDLength))
+	}
+	for _, r := range memberID {
+		if (r < '0' || r > '9') && (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return models.NewValidationError("member_id", "invalid member_id: must be alphanumeric")
+		}
+	}
+	return nil
+}
+
+func (v *Validator) ValidateServiceDate(serviceDate string) error {
+	if _, err := time.Parse(ServiceDateLayout, serviceDate); err != nil {
+		return models.NewValidationError("service_date", "invalid service_date: must be a date (YYYY-MM-DD)")
+	}
+	if serviceDate > time.Now().Format(ServiceDateLayout) {
+		return models.NewValidationError("service_date", "invalid service_date: must not be in the future")
+	}
+	return nil
+}
+
+func (v *Validator) ValidatePrescription(claim models.Claim) error {
+	if err := v.ValidateDaysSupply(claim.DaysSupply); err != nil {
+		return err
+	}
+
+	if claim.DAWCode != "" {
+		if err := v.ValidateDAWCode(claim.DAWCode); err != nil {
+			return err
+		}
+	}
+
+	if err := v.ValidateFillNumber(claim.FillNumber); err != nil {
+		return err
+	}
+
+	if claim.PrescriptionNumber != "" {
+		if err := v.ValidatePrescriptionNumber(claim.PrescriptionNumber); err != nil {
+			return err
+		}
+	}
+
+	if claim.PrescriberNPI != "" {
+		if err := v.ValidatePrescriberNPI(claim.PrescriberNPI); err != nil {
+			return err
+		}
+	}
+
+	if claim.DateWritten != nil {
+		if err := v.ValidateDateWritten(*claim.DateWritten, claim.ServiceDate); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) ValidateDaysSupply(daysSupply int) error {
+	if daysSupply < 0 || daysSupply > MaxDaysSupply {
+		return models.NewValidationError("days_supply", fmt.Sprintf("invalid days_supply: must be between 0 and %d", MaxDaysSupply))
+	}
+	return nil
+}
+
+func (v *Validator) ValidateDAWCode(code string) error {
+	if len(code) != 1 || code[0] < '0' || code[0] > '9' {
+		return models.NewValidationError("daw_code", "invalid daw_code: must be a single digit from 0 to 9")
+	}
+	return nil
+}
+
+func (v *Validator) ValidateFillNumber(fillNumber int) error {
+	if fillNumber < 0 || fillNumber > MaxFillNumber {
+		return models.NewValidationError("fill_number", fmt.Sprintf("invalid fill_number: must be between 0 and %d", MaxFillNumber))
+	}
+	return nil
+}
+
+func (v *Validator) ValidatePrescriptionNumber(number string) error {
+	if len(number) > MaxPrescriptionNumberDigits {
+		return models.NewValidationError("prescription_number", fmt.Sprintf("invalid prescription_number: must be at most %d digits", MaxPrescriptionNumberDigits))
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return models.NewValidationError("prescription_number", "invalid prescription_number: must be numeric")
+		}
+	}
+	return nil
+}
+
+func (v *Validator) ValidateDateWritten(dateWritten, serviceDate time.Time) error {
+	if dateWritten.After(serviceDate) {
+		return models.NewValidationError("date_written", "invalid date_written: must not be after the service date")
+	}
+	if serviceDate.After(dateWritten.AddDate(0, 0, MaxPrescriptionAgeDays)) {
+		return models.NewValidationError("date_written", fmt.Sprintf("invalid date_written: must be within %d days before the service date", MaxPrescriptionAgeDays))
+	}
+	return nil
+}
+
+func (v *Validator) ValidatePrescriberNPI(npi string) error {
+	if err := validateNPI("prescriber_npi", "prescriber_npi", npi); err != nil {
+		return err
+	}
+	if !nppes.ValidNPI(npi) {
+		return models.NewValidationError("prescriber_npi", "invalid prescriber_npi: check digit does not match")
+	}
+	return nil
+}
+
+func validateNPI(field, label, npi string) error {
+	if len(npi) != nppes.NPILength {
+		return models.NewValidationError(field, fmt.Sprintf("invalid %s: must be exactly %d digits", label, nppes.NPILength))
+	}
+	for _, r := range npi {
+		if r < '0' || r > '9' {
+			return models.NewValidationError(field, fmt.Sprintf("invalid %s: must be numeric", label))
+		}
+	}
+	return nil
+}
+
+func (v *Validator) ValidateChain(chain string) error {
+	for _, valid := range ValidChains {
+		if chain == valid {
+			return nil
+		}
+	}
+	return models.NewValidationError("chain", fmt.Sprintf("invalid chain: must be one of %s", strings.Join(ValidChains, ", ")))
+}
+
 func (v *Validator) ValidateQuantity(quantity float64) error {
 	if quantity <= 0 {
-		return fmt.Errorf("invalid quantity: must be greater than 0")
+		return models.NewValidationError("quantity", "invalid quantity: must be greater than 0")
 	}
 	return nil
 }
 
 func (v *Validator) ValidatePrice(price float64) error {
 	if price < 0 {
-		return fmt.Errorf("invalid price: must be non-negative")
+		return models.NewValidationError("price", "invalid price: must be non-negative")
 	}
 	return nil
 }
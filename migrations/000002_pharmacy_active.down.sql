@@ -0,0 +1 @@
+ALTER TABLE pharmacies DROP COLUMN IF EXISTS active;
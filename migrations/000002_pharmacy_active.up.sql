@@ -0,0 +1 @@
+ALTER TABLE pharmacies ADD COLUMN IF NOT EXISTS active BOOLEAN NOT NULL DEFAULT TRUE;
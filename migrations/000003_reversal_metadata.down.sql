@@ -0,0 +1,7 @@
+ALTER TABLE reversals DROP CONSTRAINT IF EXISTS valid_reason_code;
+
+ALTER TABLE reversals
+    DROP COLUMN IF EXISTS source,
+    DROP COLUMN IF EXISTS requested_by,
+    DROP COLUMN IF EXISTS reason_code,
+    DROP COLUMN IF EXISTS reason;
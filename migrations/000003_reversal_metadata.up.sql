@@ -0,0 +1,12 @@
+ALTER TABLE reversals
+    ADD COLUMN IF NOT EXISTS reason TEXT,
+    ADD COLUMN IF NOT EXISTS reason_code VARCHAR(30),
+    ADD COLUMN IF NOT EXISTS requested_by VARCHAR(100),
+    ADD COLUMN IF NOT EXISTS source VARCHAR(20);
+
+ALTER TABLE reversals ADD CONSTRAINT valid_reason_code CHECK (
+    reason_code IS NULL OR reason_code IN (
+        'billing_error', 'not_picked_up', 'duplicate_claim', 'wrong_drug',
+        'wrong_quantity', 'wrong_patient', 'other'
+    )
+);
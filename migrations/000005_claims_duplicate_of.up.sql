@@ -0,0 +1 @@
+ALTER TABLE claims ADD COLUMN IF NOT EXISTS duplicate_of UUID REFERENCES claims(id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_claims_original_claim_id;
+
+ALTER TABLE claims DROP COLUMN IF EXISTS original_claim_id;
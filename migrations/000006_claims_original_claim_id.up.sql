@@ -0,0 +1,3 @@
+ALTER TABLE claims ADD COLUMN IF NOT EXISTS original_claim_id UUID REFERENCES claims(id);
+
+CREATE INDEX IF NOT EXISTS idx_claims_original_claim_id ON claims(original_claim_id);
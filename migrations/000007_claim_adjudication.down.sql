@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_claims_status;
+
+ALTER TABLE claims DROP CONSTRAINT IF EXISTS valid_status;
+
+ALTER TABLE claims
+    DROP COLUMN IF EXISTS rejects,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,7 @@
+ALTER TABLE claims
+    ADD COLUMN IF NOT EXISTS status VARCHAR(10) NOT NULL DEFAULT 'paid',
+    ADD COLUMN IF NOT EXISTS rejects JSONB;
+
+ALTER TABLE claims ADD CONSTRAINT valid_status CHECK (status IN ('paid', 'rejected'));
+
+CREATE INDEX IF NOT EXISTS idx_claims_status ON claims(status);
@@ -0,0 +1,9 @@
+ALTER TABLE claims
+    DROP COLUMN IF EXISTS allowed_amount,
+    DROP COLUMN IF EXISTS dispensing_fee,
+    DROP COLUMN IF EXISTS ingredient_cost,
+    DROP COLUMN IF EXISTS pricing_basis;
+
+DROP INDEX IF EXISTS idx_drug_prices_ndc_effective;
+
+DROP TABLE IF EXISTS drug_prices;
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS drug_prices (
+    id SERIAL PRIMARY KEY,
+    ndc VARCHAR(11) NOT NULL,
+    awp DECIMAL(12,5),
+    wac DECIMAL(12,5),
+    mac DECIMAL(12,5),
+    nadac DECIMAL(12,5),
+    effective_date DATE NOT NULL,
+
+    CONSTRAINT unique_drug_price UNIQUE (ndc, effective_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_drug_prices_ndc_effective ON drug_prices(ndc, effective_date DESC);
+
+ALTER TABLE claims
+    ADD COLUMN IF NOT EXISTS pricing_basis VARCHAR(10) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS ingredient_cost DECIMAL(10,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS dispensing_fee DECIMAL(10,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS allowed_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+UPDATE claims
+SET pricing_basis = 'submitted', ingredient_cost = price, allowed_amount = price
+WHERE status = 'paid' AND pricing_basis = '';
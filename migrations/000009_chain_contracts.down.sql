@@ -0,0 +1,9 @@
+ALTER TABLE claims DROP COLUMN IF EXISTS contract_id;
+
+DROP INDEX IF EXISTS idx_chain_contract_history_contract;
+
+DROP TABLE IF EXISTS chain_contract_history;
+
+DROP INDEX IF EXISTS idx_chain_contracts_chain;
+
+DROP TABLE IF EXISTS chain_contracts;
@@ -0,0 +1,45 @@
+CREATE TABLE IF NOT EXISTS chain_contracts (
+    id SERIAL PRIMARY KEY,
+    chain VARCHAR(20) NOT NULL,
+    basis VARCHAR(10) NOT NULL,
+    percent DECIMAL(7,3) NOT NULL DEFAULT 0,
+    dispensing_fee DECIMAL(10,2) NOT NULL DEFAULT 0,
+    effective_from DATE NOT NULL,
+    effective_to DATE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_contract_basis CHECK (basis IN ('awp', 'wac', 'mac', 'nadac')),
+    CONSTRAINT valid_contract_range CHECK (effective_to IS NULL OR effective_to >= effective_from)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chain_contracts_chain ON chain_contracts(chain, effective_from);
+
+CREATE TABLE IF NOT EXISTS chain_contract_history (
+    id SERIAL PRIMARY KEY,
+    contract_id INTEGER NOT NULL,
+    action VARCHAR(10) NOT NULL,
+    chain VARCHAR(20) NOT NULL,
+    basis VARCHAR(10) NOT NULL,
+    percent DECIMAL(7,3) NOT NULL,
+    dispensing_fee DECIMAL(10,2) NOT NULL,
+    effective_from DATE NOT NULL,
+    effective_to DATE,
+    requested_by VARCHAR(100),
+    timestamp TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    FOREIGN KEY (contract_id) REFERENCES chain_contracts(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chain_contract_history_contract ON chain_contract_history(contract_id, timestamp);
+
+ALTER TABLE claims ADD COLUMN IF NOT EXISTS contract_id INTEGER REFERENCES chain_contracts(id);
+
+INSERT INTO chain_contracts (chain, basis, percent, dispensing_fee, effective_from)
+VALUES
+    ('health', 'awp', -18, 1.50, '2024-01-01'),
+    ('saint', 'nadac', 0, 10.00, '2024-01-01');
+
+INSERT INTO chain_contract_history (contract_id, action, chain, basis, percent, dispensing_fee, effective_from, effective_to, requested_by)
+SELECT id, 'created', chain, basis, percent, dispensing_fee, effective_from, effective_to, 'migration'
+FROM chain_contracts;
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_claims_service_date;
+DROP INDEX IF EXISTS idx_claims_member_id;
+
+ALTER TABLE claims
+    DROP COLUMN IF EXISTS service_date,
+    DROP COLUMN IF EXISTS member_id;
+
+DROP INDEX IF EXISTS idx_members_group;
+
+DROP TABLE IF EXISTS members;
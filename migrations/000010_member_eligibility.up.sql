@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS members (
+    id SERIAL PRIMARY KEY,
+    member_id VARCHAR(20) UNIQUE NOT NULL,
+    group_id VARCHAR(15) NOT NULL,
+    plan_id VARCHAR(20) NOT NULL,
+    effective_date DATE NOT NULL,
+    termination_date DATE,
+
+    CONSTRAINT valid_coverage CHECK (termination_date IS NULL OR termination_date >= effective_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_members_group ON members(group_id);
+
+ALTER TABLE claims
+    ADD COLUMN IF NOT EXISTS member_id VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS service_date DATE;
+
+UPDATE claims SET service_date = timestamp::date WHERE service_date IS NULL;
+
+ALTER TABLE claims ALTER COLUMN service_date SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_claims_member_id ON claims(member_id);
+CREATE INDEX IF NOT EXISTS idx_claims_service_date ON claims(service_date);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS formulary_entries;
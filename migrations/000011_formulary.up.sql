@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS formulary_entries (
+    id SERIAL PRIMARY KEY,
+    plan_id VARCHAR(20) NOT NULL,
+    ndc VARCHAR(11) NOT NULL,
+    tier SMALLINT NOT NULL,
+    quantity_limit DECIMAL(10,2),
+    prior_auth_required BOOLEAN NOT NULL DEFAULT FALSE,
+    step_therapy_required BOOLEAN NOT NULL DEFAULT FALSE,
+
+    CONSTRAINT unique_formulary_entry UNIQUE (plan_id, ndc),
+    CONSTRAINT valid_tier CHECK (tier BETWEEN 1 AND 5)
+);
@@ -0,0 +1,9 @@
+ALTER TABLE claims
+    DROP COLUMN IF EXISTS plan_paid_amount,
+    DROP COLUMN IF EXISTS patient_pay_amount,
+    DROP COLUMN IF EXISTS coinsurance_amount,
+    DROP COLUMN IF EXISTS copay_amount,
+    DROP COLUMN IF EXISTS deductible_amount;
+
+DROP TABLE IF EXISTS benefit_plan_tiers;
+DROP TABLE IF EXISTS benefit_plans;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS benefit_plans (
+    plan_id VARCHAR(20) PRIMARY KEY,
+    deductible DECIMAL(10,2) NOT NULL DEFAULT 0,
+    oop_max DECIMAL(10,2),
+
+    CONSTRAINT valid_benefit_amounts CHECK (deductible >= 0 AND (oop_max IS NULL OR oop_max >= deductible))
+);
+
+CREATE TABLE IF NOT EXISTS benefit_plan_tiers (
+    id SERIAL PRIMARY KEY,
+    plan_id VARCHAR(20) NOT NULL REFERENCES benefit_plans(plan_id),
+    tier SMALLINT NOT NULL,
+    copay DECIMAL(10,2) NOT NULL DEFAULT 0,
+    coinsurance_percent DECIMAL(5,2) NOT NULL DEFAULT 0,
+
+    CONSTRAINT unique_benefit_plan_tier UNIQUE (plan_id, tier),
+    CONSTRAINT valid_benefit_tier CHECK (tier BETWEEN 1 AND 5),
+    CONSTRAINT valid_tier_cost_share CHECK (
+        copay >= 0
+        AND coinsurance_percent BETWEEN 0 AND 100
+        AND (copay = 0 OR coinsurance_percent = 0)
+    )
+);
+
+ALTER TABLE claims
+    ADD COLUMN IF NOT EXISTS deductible_amount DECIMAL(10,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS copay_amount DECIMAL(10,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS coinsurance_amount DECIMAL(10,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS patient_pay_amount DECIMAL(10,2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS plan_paid_amount DECIMAL(10,2) NOT NULL DEFAULT 0;
+
+UPDATE claims SET plan_paid_amount = allowed_amount WHERE status = 'paid';
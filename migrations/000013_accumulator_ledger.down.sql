@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS accumulator_entries_append_only ON accumulator_entries;
+DROP FUNCTION IF EXISTS reject_accumulator_entry_change();
+DROP TABLE IF EXISTS accumulator_entries;
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS accumulator_entries (
+    id BIGSERIAL PRIMARY KEY,
+    member_id VARCHAR(20) NOT NULL REFERENCES members(member_id),
+    plan_year SMALLINT NOT NULL,
+    claim_id UUID REFERENCES claims(id),
+    entry_type VARCHAR(20) NOT NULL,
+    deductible_amount DECIMAL(10,2) NOT NULL DEFAULT 0,
+    oop_amount DECIMAL(10,2) NOT NULL DEFAULT 0,
+    posted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_entry_type CHECK (entry_type IN ('claim', 'reversal', 'adjustment'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_accumulator_entries_member_year ON accumulator_entries(member_id, plan_year, posted_at);
+CREATE INDEX IF NOT EXISTS idx_accumulator_entries_claim_id ON accumulator_entries(claim_id);
+
+CREATE OR REPLACE FUNCTION reject_accumulator_entry_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'accumulator_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER accumulator_entries_append_only
+    BEFORE UPDATE OR DELETE ON accumulator_entries
+    FOR EACH ROW EXECUTE FUNCTION reject_accumulator_entry_change();
+
+INSERT INTO accumulator_entries (member_id, plan_year, claim_id, entry_type, deductible_amount, oop_amount, posted_at)
+SELECT c.member_id, EXTRACT(YEAR FROM c.service_date), c.id, 'claim', c.deductible_amount, c.patient_pay_amount, c.timestamp
+FROM claims c
+WHERE c.status = 'paid' AND (c.deductible_amount <> 0 OR c.patient_pay_amount <> 0);
+
+INSERT INTO accumulator_entries (member_id, plan_year, claim_id, entry_type, deductible_amount, oop_amount, posted_at)
+SELECT c.member_id, EXTRACT(YEAR FROM c.service_date), c.id, 'reversal', -c.deductible_amount, -c.patient_pay_amount, r.timestamp
+FROM claims c
+JOIN reversals r ON r.claim_id = c.id
+WHERE c.status = 'paid' AND (c.deductible_amount <> 0 OR c.patient_pay_amount <> 0);
+
+DROP TABLE IF EXISTS member_accumulators;
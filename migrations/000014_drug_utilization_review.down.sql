@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_claims_member_service;
+
+ALTER TABLE claims
+    DROP COLUMN IF EXISTS dur,
+    DROP COLUMN IF EXISTS days_supply;
+
+DROP TABLE IF EXISTS drug_interactions;
+
+DROP INDEX IF EXISTS idx_drug_classes_class;
+
+DROP TABLE IF EXISTS drug_classes;
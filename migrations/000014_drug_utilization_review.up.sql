@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS drug_classes (
+    ndc VARCHAR(11) PRIMARY KEY,
+    drug_class VARCHAR(50) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_drug_classes_class ON drug_classes(drug_class);
+
+CREATE TABLE IF NOT EXISTS drug_interactions (
+    id SERIAL PRIMARY KEY,
+    class_a VARCHAR(50) NOT NULL,
+    class_b VARCHAR(50) NOT NULL,
+    severity VARCHAR(10) NOT NULL,
+    description VARCHAR(255) NOT NULL,
+
+    CONSTRAINT unique_drug_interaction UNIQUE (class_a, class_b),
+    CONSTRAINT ordered_drug_interaction CHECK (class_a < class_b),
+    CONSTRAINT valid_interaction_severity CHECK (severity IN ('major', 'moderate', 'minor'))
+);
+
+ALTER TABLE claims
+    ADD COLUMN IF NOT EXISTS days_supply INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS dur JSONB;
+
+CREATE INDEX IF NOT EXISTS idx_claims_member_service ON claims(member_id, service_date);
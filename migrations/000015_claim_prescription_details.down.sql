@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_claims_date_written;
+DROP INDEX IF EXISTS idx_claims_prescriber_npi;
+DROP INDEX IF EXISTS idx_claims_prescription_number;
+
+ALTER TABLE claims
+    DROP CONSTRAINT IF EXISTS valid_date_written,
+    DROP CONSTRAINT IF EXISTS valid_fill_number,
+    DROP CONSTRAINT IF EXISTS valid_daw_code,
+    DROP CONSTRAINT IF EXISTS valid_days_supply,
+    DROP COLUMN IF EXISTS prescriber_npi,
+    DROP COLUMN IF EXISTS date_written,
+    DROP COLUMN IF EXISTS prescription_number,
+    DROP COLUMN IF EXISTS fill_number,
+    DROP COLUMN IF EXISTS daw_code;
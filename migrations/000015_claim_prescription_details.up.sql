@@ -0,0 +1,14 @@
+ALTER TABLE claims
+    ADD COLUMN IF NOT EXISTS daw_code VARCHAR(1) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS fill_number INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS prescription_number VARCHAR(12) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS date_written DATE,
+    ADD COLUMN IF NOT EXISTS prescriber_npi VARCHAR(10) NOT NULL DEFAULT '',
+    ADD CONSTRAINT valid_days_supply CHECK (days_supply BETWEEN 0 AND 365),
+    ADD CONSTRAINT valid_daw_code CHECK (daw_code = '' OR daw_code BETWEEN '0' AND '9'),
+    ADD CONSTRAINT valid_fill_number CHECK (fill_number BETWEEN 0 AND 99),
+    ADD CONSTRAINT valid_date_written CHECK (date_written IS NULL OR date_written <= service_date);
+
+CREATE INDEX IF NOT EXISTS idx_claims_prescription_number ON claims(prescription_number);
+CREATE INDEX IF NOT EXISTS idx_claims_prescriber_npi ON claims(prescriber_npi);
+CREATE INDEX IF NOT EXISTS idx_claims_date_written ON claims(date_written);
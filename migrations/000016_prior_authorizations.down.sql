@@ -0,0 +1,5 @@
+ALTER TABLE claims DROP COLUMN IF EXISTS prior_auth_id;
+
+DROP INDEX IF EXISTS idx_prior_auths_member;
+
+DROP TABLE IF EXISTS prior_auths;
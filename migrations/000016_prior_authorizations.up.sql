@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS prior_auths (
+    id SERIAL PRIMARY KEY,
+    member_id VARCHAR(20) NOT NULL REFERENCES members(member_id),
+    ndc VARCHAR(11) NOT NULL DEFAULT '',
+    drug_class VARCHAR(50) NOT NULL DEFAULT '',
+    approved_quantity DECIMAL(10,2) NOT NULL,
+    used_quantity DECIMAL(10,2) NOT NULL DEFAULT 0,
+    effective_from DATE NOT NULL,
+    effective_to DATE NOT NULL,
+    status VARCHAR(10) NOT NULL DEFAULT 'approved',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT prior_auth_drug CHECK ((ndc = '') <> (drug_class = '')),
+    CONSTRAINT valid_prior_auth_quantity CHECK (approved_quantity > 0 AND used_quantity >= 0 AND used_quantity <= approved_quantity),
+    CONSTRAINT valid_prior_auth_range CHECK (effective_to >= effective_from),
+    CONSTRAINT valid_prior_auth_status CHECK (status IN ('pending', 'approved', 'denied'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_prior_auths_member ON prior_auths(member_id, status, effective_from);
+
+ALTER TABLE claims ADD COLUMN IF NOT EXISTS prior_auth_id INTEGER REFERENCES prior_auths(id);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS npi_registry (
+    npi VARCHAR(10) PRIMARY KEY,
+    entity_type SMALLINT NOT NULL DEFAULT 0,
+    name VARCHAR(300) NOT NULL DEFAULT '',
+    taxonomy_codes TEXT[] NOT NULL DEFAULT '{}',
+    deactivation_date DATE,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_npi_entity_type CHECK (entity_type IN (0, 1, 2))
+);
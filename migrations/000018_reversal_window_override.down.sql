@@ -0,0 +1 @@
+ALTER TABLE reversals DROP COLUMN IF EXISTS window_override;
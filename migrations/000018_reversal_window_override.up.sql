@@ -0,0 +1 @@
+ALTER TABLE reversals ADD COLUMN IF NOT EXISTS window_override BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"pharmacyclaims/internal/handlers"
+	"pharmacyclaims/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockAccumulatorService struct {
+	mock.Mock
+}
+
+func (m *MockAccumulatorService) GetAccumulator(filter models.AccumulatorFilter) (*models.Accumulator, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Accumulator), args.Error(1)
+}
+
+func newAccumulatorMux(mockService *MockAccumulatorService) *http.ServeMux {
+	mux := http.NewServeMux()
+	handlers.NewAccumulatorHandler(mockService).RegisterRoutes(mux)
+	return mux
+}
+
+func TestGetAccumulator_Success(t *testing.T) {
+	mockService := &MockAccumulatorService{}
+	mux := newAccumulatorMux(mockService)
+
+	asOf := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	filter := models.AccumulatorFilter{MemberID: "MEMBER001", PlanYear: 2025, AsOf: &asOf}
+	expected := &models.Accumulator{MemberID: "MEMBER001", PlanYear: 2025, DeductibleMet: 250, OOPMet: 410.50, AsOf: &asOf}
+	mockService.On("GetAccumulator", filter).Return(expected, nil)
+
+	req := httptest.NewRequest("GET", "/members/MEMBER001/accumulators?as_of=2025-03-01&plan_year=2025", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response models.Accumulator
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, *expected, response)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAccumulator_InvalidAsOf(t *testing.T) {
+	mockService := &MockAccumulatorService{}
+	mux := newAccumulatorMux(mockService)
+
+	req := httptest.NewRequest("GET", "/members/MEMBER001/accumulators?as_of=03/01/2025", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNotCalled(t, "GetAccumulator", mock.Anything)
+}
+
+func TestGetAccumulator_MemberNotFound(t *testing.T) {
+	mockService := &MockAccumulatorService{}
+	mux := newAccumulatorMux(mockService)
+
+	mockService.On("GetAccumulator", models.AccumulatorFilter{MemberID: "MEMBER999"}).
+		Return(nil, fmt.Errorf("%w: member_id MEMBER999", models.ErrMemberNotFound))
+
+	req := httptest.NewRequest("GET", "/members/MEMBER999/accumulators", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, models.CodeMemberNotFound, errorResponse.Code)
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,297 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"pharmacyclaims/internal/handlers"
+	"pharmacyclaims/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockContractService struct {
+	mock.Mock
+}
+
+func (m *MockContractService) ValidateContract(request models.ContractRequest) error {
+	args := m.Called(request)
+	return args.Error(0)
+}
+
+func (m *MockContractService) ListContracts(filter models.ContractFilter) ([]models.Contract, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Contract), args.Error(1)
+}
+
+func (m *MockContractService) GetContract(id int) (*models.Contract, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Contract), args.Error(1)
+}
+
+func (m *MockContractService) CreateContract(request models.ContractRequest) (*models.Contract, error) {
+	args := m.Called(request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Contract), args.Error(1)
+}
+
+func (m *MockContractService) UpdateContract(id int, request models.ContractRequest) (*models.Contract, error) {
+	args := m.Called(id, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Contract), args.Error(1)
+}
+
+func (m *MockContractService) GetContractHistory(id int) ([]models.ContractChange, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ContractChange), args.Error(1)
+}
+
+func newContractMux(mockService *MockContractService) *http.ServeMux {
+	mux := http.NewServeMux()
+	handlers.NewContractHandler(mockService).RegisterRoutes(mux)
+	return mux
+}
+
+func healthContract() *models.Contract {
+	return &models.Contract{
+		ID: 1,
+		ContractTerms: models.ContractTerms{
+			Chain:         "health",
+			Basis:         models.PriceBasisAWP,
+			Percent:       -18,
+			DispensingFee: 1.50,
+			EffectiveFrom: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestListContracts_Success(t *testing.T) {
+	mockService := &MockContractService{}
+	mux := newContractMux(mockService)
+
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	expected := []models.Contract{*healthContract()}
+	mockService.On("ListContracts", models.ContractFilter{Chain: "health", Date: &date}).Return(expected, nil)
+
+	req := httptest.NewRequest("GET", "/contracts?chain=health&date=2024-03-01", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response []models.Contract
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, expected, response)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestListContracts_InvalidDate(t *testing.T) {
+	mockService := &MockContractService{}
+	mux := newContractMux(mockService)
+
+	req := httptest.NewRequest("GET", "/contracts?date=03/01/2024", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNotCalled(t, "ListContracts", mock.Anything)
+}
+
+func TestCreateContract_Success(t *testing.T) {
+	mockService := &MockContractService{}
+	mux := newContractMux(mockService)
+
+	request := models.ContractRequest{
+		Chain:         "health",
+		Basis:         models.PriceBasisAWP,
+		Percent:       -18,
+		DispensingFee: 1.50,
+		EffectiveFrom: "2024-01-01",
+		RequestedBy:   "contracts@example.com",
+	}
+	expected := healthContract()
+
+	mockService.On("ValidateContract", request).Return(nil)
+	mockService.On("CreateContract", request).Return(expected, nil)
+
+	requestBody, _ := json.Marshal(request)
+	req := httptest.NewRequest("POST", "/contracts", bytes.NewBuffer(requestBody))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var response models.Contract
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, *expected, response)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateContract_ValidationFailed(t *testing.T) {
+	mockService := &MockContractService{}
+	mux := newContractMux(mockService)
+
+	request := models.ContractRequest{Chain: "health", Basis: "ful", EffectiveFrom: "2024-01-01"}
+	mockService.On("ValidateContract", request).Return(models.NewValidationError("basis", "invalid basis: must be one of awp, wac, mac, nadac"))
+
+	requestBody, _ := json.Marshal(request)
+	req := httptest.NewRequest("POST", "/contracts", bytes.NewBuffer(requestBody))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "basis", errorResponse.Field)
+
+	mockService.AssertNotCalled(t, "CreateContract", mock.Anything)
+}
+
+func TestCreateContract_Overlap(t *testing.T) {
+	mockService := &MockContractService{}
+	mux := newContractMux(mockService)
+
+	request := models.ContractRequest{Chain: "saint", Basis: models.PriceBasisNADAC, DispensingFee: 10, EffectiveFrom: "2024-06-01"}
+	mockService.On("ValidateContract", request).Return(nil)
+	mockService.On("CreateContract", request).Return(nil, fmt.Errorf("%w: contract 2 for chain saint", models.ErrContractOverlap))
+
+	requestBody, _ := json.Marshal(request)
+	req := httptest.NewRequest("POST", "/contracts", bytes.NewBuffer(requestBody))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, models.CodeContractOverlap, errorResponse.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateContract_Success(t *testing.T) {
+	mockService := &MockContractService{}
+	mux := newContractMux(mockService)
+
+	request := models.ContractRequest{
+		Chain:         "health",
+		Basis:         models.PriceBasisAWP,
+		Percent:       -18,
+		DispensingFee: 1.50,
+		EffectiveFrom: "2024-01-01",
+		EffectiveTo:   "2024-12-31",
+	}
+	expected := healthContract()
+	effectiveTo := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	expected.EffectiveTo = &effectiveTo
+
+	mockService.On("ValidateContract", request).Return(nil)
+	mockService.On("UpdateContract", 1, request).Return(expected, nil)
+
+	requestBody, _ := json.Marshal(request)
+	req := httptest.NewRequest("PUT", "/contracts/1", bytes.NewBuffer(requestBody))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetContract_NotFound(t *testing.T) {
+	mockService := &MockContractService{}
+	mux := newContractMux(mockService)
+
+	mockService.On("GetContract", 99).Return(nil, fmt.Errorf("%w: id 99", models.ErrContractNotFound))
+
+	req := httptest.NewRequest("GET", "/contracts/99", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetContract_InvalidID(t *testing.T) {
+	mockService := &MockContractService{}
+	mux := newContractMux(mockService)
+
+	req := httptest.NewRequest("GET", "/contracts/abc", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	mockService.AssertNotCalled(t, "GetContract", mock.Anything)
+}
+
+func TestGetContractHistory_Success(t *testing.T) {
+	mockService := &MockContractService{}
+	mux := newContractMux(mockService)
+
+	contract := healthContract()
+	expected := []models.ContractChange{
+		{ID: 1, ContractID: 1, Action: models.ContractActionCreated, ContractTerms: contract.ContractTerms, RequestedBy: "migration"},
+		{ID: 2, ContractID: 1, Action: models.ContractActionUpdated, ContractTerms: contract.ContractTerms, RequestedBy: "contracts@example.com"},
+	}
+	mockService.On("GetContractHistory", 1).Return(expected, nil)
+
+	req := httptest.NewRequest("GET", "/contracts/1/history", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response []models.ContractChange
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, expected, response)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestContracts_MethodNotAllowed(t *testing.T) {
+	mockService := &MockContractService{}
+	mux := newContractMux(mockService)
+
+	req := httptest.NewRequest("DELETE", "/contracts/1", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"pharmacyclaims/internal/handlers"
 	"pharmacyclaims/internal/models"
@@ -35,6 +36,11 @@ func (m *MockService) SubmitClaim(request models.ClaimRequest) (*models.ClaimRes
 	return args.Get(0).(*models.ClaimResponse), args.Error(1)
 }
 
+func (m *MockService) ValidateReversal(request models.ReversalRequest) error {
+	args := m.Called(request)
+	return args.Error(0)
+}
+
 func (m *MockService) ReverseClaim(request models.ReversalRequest) (*models.ReversalResponse, error) {
 	args := m.Called(request)
 	if args.Get(0) == nil {
@@ -43,6 +49,40 @@ func (m *MockService) ReverseClaim(request models.ReversalRequest) (*models.Reve
 	return args.Get(0).(*models.ReversalResponse), args.Error(1)
 }
 
+func (m *MockService) ValidateRebill(request models.RebillRequest) error {
+	args := m.Called(request)
+	return args.Error(0)
+}
+
+func (m *MockService) RebillClaim(request models.RebillRequest) (*models.RebillResponse, error) {
+	args := m.Called(request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RebillResponse), args.Error(1)
+}
+
+func (m *MockService) GetClaim(id uuid.UUID) (*models.ClaimDetails, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClaimDetails), args.Error(1)
+}
+
+func (m *MockService) ValidateClaimSearch(filter models.ClaimSearchFilter) error {
+	args := m.Called(filter)
+	return args.Error(0)
+}
+
+func (m *MockService) SearchClaims(filter models.ClaimSearchFilter) (*models.ClaimSearchResponse, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClaimSearchResponse), args.Error(1)
+}
+
 func TestNewHttpHandler(t *testing.T) {
 	mockService := &MockService{}
 	handler := handlers.NewHttpHandler(mockService)
@@ -51,29 +91,50 @@ func TestNewHttpHandler(t *testing.T) {
 }
 
 func TestSetupRoutes(t *testing.T) {
-	mockService := &MockService{}
-	handler := handlers.NewHttpHandler(mockService)
-	mux := handler.SetupRoutes()
-
-	assert.NotNil(t, mux)
+	claimID := uuid.New()
 
 	testCases := []struct {
-		path   string
-		method string
+		method     string
+		path       string
+		body       string
+		setup      func(m *MockService)
+		statusCode int
 	}{
-		{"/claim", "POST"},
-		{"/reversal", "POST"},
-		{"/health", "GET"},
+		{"POST", "/claim", `{}`, func(m *MockService) {
+			m.On("ValidateClaim", mock.Anything).Return(nil)
+			m.On("SubmitClaim", mock.Anything).Return(&models.ClaimResponse{}, nil)
+		}, http.StatusCreated},
+		{"POST", "/reversal", `{"claim_id": "` + claimID.String() + `"}`, func(m *MockService) {
+			m.On("ValidateReversal", mock.Anything).Return(nil)
+			m.On("ReverseClaim", mock.Anything).Return(&models.ReversalResponse{}, nil)
+		}, http.StatusOK},
+		{"GET", "/health", "", func(m *MockService) {}, http.StatusOK},
+		{"GET", "/claims", "", func(m *MockService) {
+			m.On("ValidateClaimSearch", mock.Anything).Return(nil)
+			m.On("SearchClaims", mock.Anything).Return(&models.ClaimSearchResponse{}, nil)
+		}, http.StatusOK},
+		{"GET", "/claims/" + claimID.String(), "", func(m *MockService) {
+			m.On("GetClaim", claimID).Return(&models.ClaimDetails{}, nil)
+		}, http.StatusOK},
+		{"POST", "/claims/" + claimID.String() + "/rebill", `{}`, func(m *MockService) {
+			m.On("ValidateRebill", mock.MatchedBy(func(r models.RebillRequest) bool { return r.ClaimID == claimID })).Return(nil)
+			m.On("RebillClaim", mock.MatchedBy(func(r models.RebillRequest) bool { return r.ClaimID == claimID })).Return(&models.RebillResponse{}, nil)
+		}, http.StatusCreated},
 	}
 
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf("Route %s %s", tc.method, tc.path), func(t *testing.T) {
-			req := httptest.NewRequest(tc.method, tc.path, nil)
+			mockService := &MockService{}
+			tc.setup(mockService)
+			mux := handlers.NewHttpHandler(mockService).SetupRoutes()
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
 			rr := httptest.NewRecorder()
 
 			mux.ServeHTTP(rr, req)
 
-			assert.NotEqual(t, http.StatusNotFound, rr.Code)
+			assert.Equal(t, tc.statusCode, rr.Code)
+			mockService.AssertExpectations(t)
 		})
 	}
 }
@@ -83,15 +144,17 @@ func TestSubmitClaim_Success(t *testing.T) {
 	handler := handlers.NewHttpHandler(mockService)
 
 	claimRequest := models.ClaimRequest{
-		NDC:      "1234567890",
-		Quantity: 10.0,
-		NPI:      "1234567890",
-		Price:    29.99,
+		NDC:         "1234567890",
+		Quantity:    10.0,
+		NPI:         "1234567890",
+		Price:       29.99,
+		MemberID:    "MEMBER001",
+		ServiceDate: "2024-02-01",
 	}
 
 	claimID := uuid.New()
 	expectedResponse := &models.ClaimResponse{
-		Status:  "claim submitted",
+		Status:  models.ClaimStatusPaid,
 		ClaimID: claimID,
 	}
 
@@ -117,6 +180,150 @@ func TestSubmitClaim_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestSubmitClaim_NormalizesNDC(t *testing.T) {
+	mockService := &MockService{}
+	handler := handlers.NewHttpHandler(mockService)
+
+	normalized := models.ClaimRequest{
+		NDC:      "00002323401",
+		Quantity: 10.0,
+		NPI:      "1234567890",
+		Price:    29.99,
+	}
+	expectedResponse := &models.ClaimResponse{
+		Status:  models.ClaimStatusPaid,
+		ClaimID: uuid.New(),
+	}
+
+	mockService.On("ValidateClaim", normalized).Return(nil)
+	mockService.On("SubmitClaim", normalized).Return(expectedResponse, nil)
+
+	requestBody := `{"ndc": "0002-3234-01", "quantity": 10, "npi": "1234567890", "price": 29.99}`
+	req := httptest.NewRequest("POST", "/claim", bytes.NewBufferString(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	handler.SubmitClaim(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestSubmitClaim_IdempotencyKey(t *testing.T) {
+	mockService := &MockService{}
+	handler := handlers.NewHttpHandler(mockService)
+
+	claimRequest := models.ClaimRequest{
+		NDC:      "1234567890",
+		Quantity: 10.0,
+		NPI:      "1234567890",
+		Price:    29.99,
+	}
+	keyedRequest := claimRequest
+	keyedRequest.IdempotencyKey = "retry-42"
+
+	expectedResponse := &models.ClaimResponse{
+		Status:  models.ClaimStatusPaid,
+		ClaimID: uuid.New(),
+	}
+
+	mockService.On("ValidateClaim", keyedRequest).Return(nil)
+	mockService.On("SubmitClaim", keyedRequest).Return(expectedResponse, nil)
+
+	requestBody, _ := json.Marshal(claimRequest)
+	req := httptest.NewRequest("POST", "/claim", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", "retry-42")
+	rr := httptest.NewRecorder()
+
+	handler.SubmitClaim(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var response models.ClaimResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, expectedResponse.ClaimID, response.ClaimID)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestSubmitClaim_IdempotencyConflict(t *testing.T) {
+	mockService := &MockService{}
+	handler := handlers.NewHttpHandler(mockService)
+
+	claimRequest := models.ClaimRequest{
+		NDC:            "1234567890",
+		Quantity:       20.0,
+		NPI:            "1234567890",
+		Price:          29.99,
+		IdempotencyKey: "retry-42",
+	}
+
+	mockService.On("ValidateClaim", claimRequest).Return(nil)
+	mockService.On("SubmitClaim", claimRequest).Return(nil, fmt.Errorf("%w: retry-42", models.ErrIdempotencyConflict))
+
+	requestBody, _ := json.Marshal(claimRequest)
+	req := httptest.NewRequest("POST", "/claim", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", "retry-42")
+	rr := httptest.NewRecorder()
+
+	handler.SubmitClaim(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, models.CodeIdempotencyConflict, errorResponse.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestSubmitClaim_Rejected(t *testing.T) {
+	mockService := &MockService{}
+	handler := handlers.NewHttpHandler(mockService)
+
+	claimRequest := models.ClaimRequest{
+		NDC:      "1234567890",
+		Quantity: 10.0,
+		NPI:      "1234567890",
+		Price:    29.99,
+	}
+
+	originalID := uuid.New()
+	expectedResponse := &models.ClaimResponse{
+		Status:  models.ClaimStatusRejected,
+		ClaimID: uuid.New(),
+		Rejects: []models.Reject{
+			{Code: "83", Message: "duplicate of claim " + originalID.String()},
+			{Code: "76", Message: "quantity 20000 exceeds limit of 10000"},
+		},
+	}
+
+	mockService.On("ValidateClaim", claimRequest).Return(nil)
+	mockService.On("SubmitClaim", claimRequest).Return(expectedResponse, nil)
+
+	requestBody, _ := json.Marshal(claimRequest)
+	req := httptest.NewRequest("POST", "/claim", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	handler.SubmitClaim(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var response models.ClaimResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, models.ClaimStatusRejected, response.Status)
+	assert.Equal(t, expectedResponse.ClaimID, response.ClaimID)
+	assert.Equal(t, expectedResponse.Rejects, response.Rejects)
+
+	mockService.AssertExpectations(t)
+}
+
 func TestSubmitClaim_MethodNotAllowed(t *testing.T) {
 	mockService := &MockService{}
 	handler := handlers.NewHttpHandler(mockService)
@@ -133,6 +340,7 @@ func TestSubmitClaim_MethodNotAllowed(t *testing.T) {
 	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 	require.NoError(t, err)
 	assert.Equal(t, "Method not allowed", errorResponse.Error)
+	assert.Equal(t, models.CodeMethodNotAllowed, errorResponse.Code)
 	assert.Equal(t, "Only POST method is allowed", errorResponse.Message)
 }
 
@@ -185,6 +393,39 @@ func TestSubmitClaim_ValidationFailed(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestSubmitClaim_ValidationErrorField(t *testing.T) {
+	mockService := &MockService{}
+	handler := handlers.NewHttpHandler(mockService)
+
+	claimRequest := models.ClaimRequest{
+		NDC:      "1234567890",
+		Quantity: 0,
+		NPI:      "1234567890",
+		Price:    29.99,
+	}
+
+	mockService.On("ValidateClaim", claimRequest).Return(models.NewValidationError("quantity", "invalid quantity: must be greater than 0"))
+
+	requestBody, _ := json.Marshal(claimRequest)
+	req := httptest.NewRequest("POST", "/claim", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	handler.SubmitClaim(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Validation failed", errorResponse.Error)
+	assert.Equal(t, models.CodeValidationFailed, errorResponse.Code)
+	assert.Equal(t, "quantity", errorResponse.Field)
+	assert.Equal(t, "invalid quantity: must be greater than 0", errorResponse.Message)
+
+	mockService.AssertExpectations(t)
+}
+
 func TestSubmitClaim_PharmacyNotFound(t *testing.T) {
 	mockService := &MockService{}
 	handler := handlers.NewHttpHandler(mockService)
@@ -197,7 +438,7 @@ func TestSubmitClaim_PharmacyNotFound(t *testing.T) {
 	}
 
 	mockService.On("ValidateClaim", claimRequest).Return(nil)
-	mockService.On("SubmitClaim", claimRequest).Return(nil, fmt.Errorf("pharmacy with NPI %s not found", claimRequest.NPI))
+	mockService.On("SubmitClaim", claimRequest).Return(nil, fmt.Errorf("%w: npi %s", models.ErrPharmacyNotFound, claimRequest.NPI))
 
 	requestBody, _ := json.Marshal(claimRequest)
 	req := httptest.NewRequest("POST", "/claim", bytes.NewBuffer(requestBody))
@@ -212,6 +453,7 @@ func TestSubmitClaim_PharmacyNotFound(t *testing.T) {
 	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 	require.NoError(t, err)
 	assert.Equal(t, "Pharmacy not found", errorResponse.Error)
+	assert.Equal(t, models.CodePharmacyNotFound, errorResponse.Code)
 
 	mockService.AssertExpectations(t)
 }
@@ -243,6 +485,7 @@ func TestSubmitClaim_InternalServerError(t *testing.T) {
 	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 	require.NoError(t, err)
 	assert.Equal(t, "Failed to submit claim", errorResponse.Error)
+	assert.Equal(t, models.CodeInternalError, errorResponse.Code)
 	assert.Equal(t, "database connection failed", errorResponse.Message)
 
 	mockService.AssertExpectations(t)
@@ -254,15 +497,23 @@ func TestReverseClaim_Success(t *testing.T) {
 
 	claimID := uuid.New()
 	reversalRequest := models.ReversalRequest{
-		ClaimID: claimID,
-		Reason:  "Customer returned item",
+		ClaimID:     claimID,
+		NPI:         "1234567890",
+		Reason:      "Customer returned item",
+		ReasonCode:  "not_picked_up",
+		RequestedBy: "pharmacist@example.com",
 	}
 
 	expectedResponse := &models.ReversalResponse{
-		Status:  "claim reversed",
-		ClaimID: claimID,
+		Status:      "claim reversed",
+		ClaimID:     claimID,
+		ReversalID:  uuid.New(),
+		Reason:      "Customer returned item",
+		ReasonCode:  "not_picked_up",
+		RequestedBy: "pharmacist@example.com",
 	}
 
+	mockService.On("ValidateReversal", reversalRequest).Return(nil)
 	mockService.On("ReverseClaim", reversalRequest).Return(expectedResponse, nil)
 
 	requestBody, _ := json.Marshal(reversalRequest)
@@ -280,6 +531,9 @@ func TestReverseClaim_Success(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, expectedResponse.Status, response.Status)
 	assert.Equal(t, expectedResponse.ClaimID, response.ClaimID)
+	assert.Equal(t, expectedResponse.ReversalID, response.ReversalID)
+	assert.Equal(t, expectedResponse.ReasonCode, response.ReasonCode)
+	assert.Equal(t, expectedResponse.RequestedBy, response.RequestedBy)
 
 	mockService.AssertExpectations(t)
 }
@@ -345,6 +599,35 @@ func TestReverseClaim_InvalidClaimID(t *testing.T) {
 	assert.Equal(t, "claim_id must be a valid UUID", errorResponse.Message)
 }
 
+func TestReverseClaim_ValidationFailed(t *testing.T) {
+	mockService := &MockService{}
+	handler := handlers.NewHttpHandler(mockService)
+
+	reversalRequest := models.ReversalRequest{
+		ClaimID:    uuid.New(),
+		ReasonCode: "changed_mind",
+	}
+
+	mockService.On("ValidateReversal", reversalRequest).Return(fmt.Errorf("invalid reason_code"))
+
+	requestBody, _ := json.Marshal(reversalRequest)
+	req := httptest.NewRequest("POST", "/reversal", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	handler.ReverseClaim(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Validation failed", errorResponse.Error)
+	assert.Equal(t, "invalid reason_code", errorResponse.Message)
+
+	mockService.AssertExpectations(t)
+}
+
 func TestReverseClaim_ClaimNotFound(t *testing.T) {
 	mockService := &MockService{}
 	handler := handlers.NewHttpHandler(mockService)
@@ -355,7 +638,8 @@ func TestReverseClaim_ClaimNotFound(t *testing.T) {
 		Reason:  "Customer returned item",
 	}
 
-	mockService.On("ReverseClaim", reversalRequest).Return(nil, fmt.Errorf("claim with ID %s not found", claimID.String()))
+	mockService.On("ValidateReversal", reversalRequest).Return(nil)
+	mockService.On("ReverseClaim", reversalRequest).Return(nil, fmt.Errorf("%w: id %s", models.ErrClaimNotFound, claimID))
 
 	requestBody, _ := json.Marshal(reversalRequest)
 	req := httptest.NewRequest("POST", "/reversal", bytes.NewBuffer(requestBody))
@@ -384,7 +668,8 @@ func TestReverseClaim_ClaimAlreadyReversed(t *testing.T) {
 		Reason:  "Customer returned item",
 	}
 
-	mockService.On("ReverseClaim", reversalRequest).Return(nil, fmt.Errorf("claim is already reversed"))
+	mockService.On("ValidateReversal", reversalRequest).Return(nil)
+	mockService.On("ReverseClaim", reversalRequest).Return(nil, fmt.Errorf("%w: id %s", models.ErrAlreadyReversed, claimID))
 
 	requestBody, _ := json.Marshal(reversalRequest)
 	req := httptest.NewRequest("POST", "/reversal", bytes.NewBuffer(requestBody))
@@ -399,11 +684,113 @@ func TestReverseClaim_ClaimAlreadyReversed(t *testing.T) {
 	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
 	require.NoError(t, err)
 	assert.Equal(t, "Claim already reversed", errorResponse.Error)
-	assert.Equal(t, "claim is already reversed", errorResponse.Message)
+	assert.Equal(t, models.CodeAlreadyReversed, errorResponse.Code)
+	assert.Equal(t, "claim already reversed: id "+claimID.String(), errorResponse.Message)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestReverseClaim_ReversalMismatch(t *testing.T) {
+	mockService := &MockService{}
+	handler := handlers.NewHttpHandler(mockService)
+
+	claimID := uuid.New()
+	reversalRequest := models.ReversalRequest{
+		ClaimID: claimID,
+		NPI:     "9876543210",
+	}
+
+	mockService.On("ValidateReversal", reversalRequest).Return(nil)
+	mockService.On("ReverseClaim", reversalRequest).Return(nil, fmt.Errorf("%w: npi 9876543210 did not submit claim %s", models.ErrReversalMismatch, claimID))
+
+	requestBody, _ := json.Marshal(reversalRequest)
+	req := httptest.NewRequest("POST", "/reversal", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	handler.ReverseClaim(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, models.CodeReversalMismatch, errorResponse.Code)
+	assert.Contains(t, errorResponse.Message, claimID.String())
+
+	mockService.AssertExpectations(t)
+}
+
+func TestReverseClaim_ReversalWindowExpired(t *testing.T) {
+	mockService := &MockService{}
+	handler := handlers.NewHttpHandlerWithRoleTokens(mockService, map[string]string{"supervisor": "supervisor-token"})
+
+	claimID := uuid.New()
+	reversalRequest := models.ReversalRequest{
+		ClaimID: claimID,
+		NPI:     "1234567890",
+	}
+
+	mockService.On("ValidateReversal", reversalRequest).Return(nil)
+	mockService.On("ReverseClaim", reversalRequest).Return(nil, fmt.Errorf("%w: claim %s", models.ErrReversalWindow, claimID))
+
+	requestBody, _ := json.Marshal(reversalRequest)
+	req := httptest.NewRequest("POST", "/reversal", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-User-Role", "supervisor")
+	rr := httptest.NewRecorder()
+
+	handler.ReverseClaim(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, models.CodeReversalWindow, errorResponse.Code)
 
 	mockService.AssertExpectations(t)
 }
 
+func TestReverseClaim_RoleFromBearerToken(t *testing.T) {
+	roleTokens := map[string]string{"supervisor": "supervisor-token"}
+
+	tests := []struct {
+		name          string
+		authorization string
+		role          string
+	}{
+		{"configured token", "Bearer supervisor-token", "supervisor"},
+		{"unknown token", "Bearer guessed-token", ""},
+		{"not a bearer token", "supervisor-token", ""},
+		{"no token", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockService{}
+			handler := handlers.NewHttpHandlerWithRoleTokens(mockService, roleTokens)
+
+			expectedRequest := models.ReversalRequest{ClaimID: uuid.New(), NPI: "1234567890", Role: tt.role}
+			mockService.On("ValidateReversal", expectedRequest).Return(nil)
+			mockService.On("ReverseClaim", expectedRequest).Return(&models.ReversalResponse{ClaimID: expectedRequest.ClaimID}, nil)
+
+			requestBody, _ := json.Marshal(expectedRequest)
+			req := httptest.NewRequest("POST", "/reversal", bytes.NewBuffer(requestBody))
+			req.Header.Set("X-User-Role", "supervisor")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ReverseClaim(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
 func TestReverseClaim_InternalServerError(t *testing.T) {
 	mockService := &MockService{}
 	handler := handlers.NewHttpHandler(mockService)
@@ -414,6 +801,7 @@ func TestReverseClaim_InternalServerError(t *testing.T) {
 		Reason:  "Customer returned item",
 	}
 
+	mockService.On("ValidateReversal", reversalRequest).Return(nil)
 	mockService.On("ReverseClaim", reversalRequest).Return(nil, fmt.Errorf("database connection failed"))
 
 	requestBody, _ := json.Marshal(reversalRequest)
@@ -434,6 +822,345 @@ func TestReverseClaim_InternalServerError(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestGetClaim_Success(t *testing.T) {
+	mockService := &MockService{}
+	handler := handlers.NewHttpHandler(mockService)
+	mux := handler.SetupRoutes()
+
+	claimID := uuid.New()
+	reversedAt := time.Date(2024, 2, 2, 8, 48, 7, 0, time.UTC)
+	expected := &models.ClaimDetails{
+		Claim: models.Claim{
+			ID:        claimID,
+			NDC:       "00002323401",
+			Quantity:  30,
+			NPI:       "1234567890",
+			Price:     25.99,
+			Timestamp: models.CustomTime{Time: time.Date(2024, 2, 1, 10, 0, 0, 0, time.UTC)},
+		},
+		Reversed:   true,
+		ReversedAt: &reversedAt,
+	}
+
+	mockService.On("GetClaim", claimID).Return(expected, nil)
+
+	req := httptest.NewRequest("GET", "/claims/"+claimID.String(), nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response map[string]interface{}
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, claimID.String(), response["id"])
+	assert.Equal(t, true, response["reversed"])
+	assert.Equal(t, "2024-02-02T08:48:07Z", response["reversed_at"])
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetClaim_InvalidID(t *testing.T) {
+	mockService := &MockService{}
+	mux := handlers.NewHttpHandler(mockService).SetupRoutes()
+
+	req := httptest.NewRequest("GET", "/claims/not-a-uuid", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Invalid claim_id", errorResponse.Error)
+}
+
+func TestGetClaim_NotFound(t *testing.T) {
+	mockService := &MockService{}
+	mux := handlers.NewHttpHandler(mockService).SetupRoutes()
+
+	claimID := uuid.New()
+	mockService.On("GetClaim", claimID).Return(nil, fmt.Errorf("%w: id %s", models.ErrClaimNotFound, claimID))
+
+	req := httptest.NewRequest("GET", "/claims/"+claimID.String(), nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Claim not found", errorResponse.Error)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestRebillClaim_Success(t *testing.T) {
+	mockService := &MockService{}
+	mux := handlers.NewHttpHandler(mockService).SetupRoutes()
+
+	originalID := uuid.New()
+	rebillRequest := models.RebillRequest{
+		NDC:        "00002323401",
+		Quantity:   30,
+		NPI:        "1234567890",
+		Price:      24.50,
+		ReasonCode: "wrong_quantity",
+	}
+	expectedRequest := rebillRequest
+	expectedRequest.ClaimID = originalID
+
+	expectedResponse := &models.RebillResponse{
+		Status:          "claim rebilled",
+		OriginalClaimID: originalID,
+		ClaimID:         uuid.New(),
+		ReversalID:      uuid.New(),
+	}
+
+	mockService.On("ValidateRebill", expectedRequest).Return(nil)
+	mockService.On("RebillClaim", expectedRequest).Return(expectedResponse, nil)
+
+	requestBody, _ := json.Marshal(rebillRequest)
+	req := httptest.NewRequest("POST", "/claims/"+originalID.String()+"/rebill", bytes.NewBuffer(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var response models.RebillResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, expectedResponse.Status, response.Status)
+	assert.Equal(t, originalID, response.OriginalClaimID)
+	assert.Equal(t, expectedResponse.ClaimID, response.ClaimID)
+	assert.Equal(t, expectedResponse.ReversalID, response.ReversalID)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestRebillClaim_InvalidID(t *testing.T) {
+	mockService := &MockService{}
+	mux := handlers.NewHttpHandler(mockService).SetupRoutes()
+
+	req := httptest.NewRequest("POST", "/claims/not-a-uuid/rebill", strings.NewReader("{}"))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, models.CodeInvalidParameter, errorResponse.Code)
+}
+
+func TestRebillClaim_Rejected(t *testing.T) {
+	mockService := &MockService{}
+	mux := handlers.NewHttpHandler(mockService).SetupRoutes()
+
+	originalID := uuid.New()
+	rebillRequest := models.RebillRequest{
+		NDC:      "00002323401",
+		Quantity: 30,
+		NPI:      "1234567890",
+		Price:    24.50,
+	}
+	expectedRequest := rebillRequest
+	expectedRequest.ClaimID = originalID
+
+	mockService.On("ValidateRebill", expectedRequest).Return(nil)
+	rejects := []models.Reject{
+		{Code: "40", Message: "pharmacy 1234567890 is inactive"},
+		{Code: "76", Message: "quantity 30 exceeds the plan limit of 20"},
+	}
+	mockService.On("RebillClaim", expectedRequest).Return(nil, models.NewRejectedError(rejects))
+
+	requestBody, _ := json.Marshal(rebillRequest)
+	req := httptest.NewRequest("POST", "/claims/"+originalID.String()+"/rebill", bytes.NewBuffer(requestBody))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, models.CodeClaimRejected, errorResponse.Code)
+	assert.Equal(t, rejects, errorResponse.Rejects)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestRebillClaim_AlreadyReversed(t *testing.T) {
+	mockService := &MockService{}
+	mux := handlers.NewHttpHandler(mockService).SetupRoutes()
+
+	originalID := uuid.New()
+	rebillRequest := models.RebillRequest{
+		NDC:      "00002323401",
+		Quantity: 30,
+		NPI:      "1234567890",
+		Price:    24.50,
+	}
+	expectedRequest := rebillRequest
+	expectedRequest.ClaimID = originalID
+
+	mockService.On("ValidateRebill", expectedRequest).Return(nil)
+	mockService.On("RebillClaim", expectedRequest).Return(nil, fmt.Errorf("%w: id %s", models.ErrAlreadyReversed, originalID))
+
+	requestBody, _ := json.Marshal(rebillRequest)
+	req := httptest.NewRequest("POST", "/claims/"+originalID.String()+"/rebill", bytes.NewBuffer(requestBody))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, models.CodeAlreadyReversed, errorResponse.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestSearchClaims_Success(t *testing.T) {
+	mockService := &MockService{}
+	handler := handlers.NewHttpHandler(mockService)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	reversed := false
+	filter := models.ClaimSearchFilter{
+		NPI:      "1234567890",
+		NDC:      "00002323401",
+		From:     &from,
+		To:       &to,
+		Status:   models.ClaimStatusPaid,
+		Reversed: &reversed,
+		Limit:    10,
+	}
+	expected := &models.ClaimSearchResponse{
+		Claims:     []models.ClaimDetails{{Claim: models.Claim{ID: uuid.New(), NPI: "1234567890"}}},
+		NextCursor: "next",
+	}
+
+	mockService.On("ValidateClaimSearch", filter).Return(nil)
+	mockService.On("SearchClaims", filter).Return(expected, nil)
+
+	req := httptest.NewRequest("GET", "/claims?npi=1234567890&ndc=00002323401&from=2024-01-01&to=2024-01-31&status=paid&reversed=false&limit=10", nil)
+	rr := httptest.NewRecorder()
+
+	handler.SearchClaims(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response models.ClaimSearchResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, "next", response.NextCursor)
+	assert.Len(t, response.Claims, 1)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestSearchClaims_PrescriptionFilters(t *testing.T) {
+	mockService := &MockService{}
+	handler := handlers.NewHttpHandler(mockService)
+
+	daysSupply := 30
+	fillNumber := 2
+	dateWritten := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+	filter := models.ClaimSearchFilter{
+		DaysSupply:         &daysSupply,
+		DAWCode:            "1",
+		FillNumber:         &fillNumber,
+		PrescriptionNumber: "123456",
+		DateWritten:        &dateWritten,
+		PrescriberNPI:      "1987654321",
+	}
+	expected := &models.ClaimSearchResponse{Claims: []models.ClaimDetails{}}
+
+	mockService.On("ValidateClaimSearch", filter).Return(nil)
+	mockService.On("SearchClaims", filter).Return(expected, nil)
+
+	req := httptest.NewRequest("GET", "/claims?days_supply=30&daw_code=1&fill_number=2&prescription_number=123456&date_written=2024-01-15&prescriber_npi=1987654321", nil)
+	rr := httptest.NewRecorder()
+
+	handler.SearchClaims(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestSearchClaims_InvalidParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		errorMsg string
+	}{
+		{"Invalid from", "from=yesterday", "Invalid from"},
+		{"Invalid to", "to=01/02/2024", "Invalid to"},
+		{"Invalid reversed", "reversed=maybe", "Invalid reversed"},
+		{"Invalid limit", "limit=ten", "Invalid limit"},
+		{"Invalid days_supply", "days_supply=thirty", "Invalid days_supply"},
+		{"Invalid fill_number", "fill_number=first", "Invalid fill_number"},
+		{"Invalid date_written", "date_written=2024/01/15", "Invalid date_written"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := &MockService{}
+			handler := handlers.NewHttpHandler(mockService)
+
+			req := httptest.NewRequest("GET", "/claims?"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			handler.SearchClaims(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+			var errorResponse models.ErrorResponse
+			err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+			require.NoError(t, err)
+			assert.Equal(t, tt.errorMsg, errorResponse.Error)
+		})
+	}
+}
+
+func TestSearchClaims_ValidationFailed(t *testing.T) {
+	mockService := &MockService{}
+	handler := handlers.NewHttpHandler(mockService)
+
+	filter := models.ClaimSearchFilter{Cursor: "garbage"}
+	mockService.On("ValidateClaimSearch", filter).Return(fmt.Errorf("invalid cursor"))
+
+	req := httptest.NewRequest("GET", "/claims?cursor=garbage", nil)
+	rr := httptest.NewRecorder()
+
+	handler.SearchClaims(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Validation failed", errorResponse.Error)
+	assert.Equal(t, "invalid cursor", errorResponse.Message)
+
+	mockService.AssertExpectations(t)
+}
+
 func TestHealthCheck_Success(t *testing.T) {
 	mockService := &MockService{}
 	handler := handlers.NewHttpHandler(mockService)
@@ -0,0 +1,398 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"pharmacyclaims/internal/handlers"
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/ncpdp"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockNCPDPService struct {
+	mock.Mock
+}
+
+func (m *MockNCPDPService) SubmitClaim(request models.ClaimRequest) (*models.ClaimResponse, error) {
+	args := m.Called(request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClaimResponse), args.Error(1)
+}
+
+func (m *MockNCPDPService) ReverseClaim(request models.ReversalRequest) (*models.ReversalResponse, error) {
+	args := m.Called(request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ReversalResponse), args.Error(1)
+}
+
+func (m *MockNCPDPService) FindClaimForReversal(match models.ReversalMatch) (*models.Claim, error) {
+	args := m.Called(match)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Claim), args.Error(1)
+}
+
+const (
+	ncpdpSS = "\x1e"
+	ncpdpGS = "\x1d"
+	ncpdpFS = "\x1c"
+)
+
+func ncpdpTransmission(transactionCode string) string {
+	raw := "610020D0" + transactionCode + "PCN1234567" + "1" + "01" + "1234567890     " + "20240201" + "VENDOR0001" +
+		ncpdpSS + ncpdpFS + "AM04" + ncpdpFS + "C2MEMBER001" +
+		ncpdpGS +
+		ncpdpSS + ncpdpFS + "AM07" + ncpdpFS + "EM1" + ncpdpFS + "D2000000123456" + ncpdpFS + "E103" + ncpdpFS + "D700002323401"
+	if transactionCode == ncpdp.TransactionBilling {
+		raw += ncpdpFS + "E730000" + ncpdpSS + ncpdpFS + "AM11" + ncpdpFS + "DU250{"
+	}
+	return raw
+}
+
+func postNCPDP(mockService *MockNCPDPService, body string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	handlers.NewNCPDPHandler(mockService).RegisterRoutes(mux)
+
+	req := httptest.NewRequest("POST", "/ncpdp", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestNCPDP_BillingPaid(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	claimID := uuid.New()
+	mockService.On("SubmitClaim", models.ClaimRequest{
+		NDC:                "00002323401",
+		Quantity:           30,
+		PrescriptionNumber: "000000123456",
+		NPI:                "1234567890",
+		Price:              25,
+		MemberID:           "MEMBER001",
+		ServiceDate:        "2024-02-01",
+	}).Return(&models.ClaimResponse{Status: models.ClaimStatusPaid, ClaimID: claimID}, nil)
+
+	rr := postNCPDP(mockService, ncpdpTransmission(ncpdp.TransactionBilling))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	assert.Equal(t, "D0B11A011234567890     20240201", body[:ncpdp.ResponseHeaderLength])
+	assert.Contains(t, body, "AM21"+ncpdpFS+"ANP")
+	assert.Contains(t, body, claimID.String())
+	assert.Contains(t, body, "AM23"+ncpdpFS+"F9250{")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestNCPDP_BillingPricedByFormula(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	mockService.On("SubmitClaim", mock.Anything).Return(&models.ClaimResponse{
+		Status:  models.ClaimStatusPaid,
+		ClaimID: uuid.New(),
+		Pricing: &models.ClaimPricing{
+			Basis:          models.PriceBasisAWP,
+			IngredientCost: 18.50,
+			DispensingFee:  2.00,
+			Total:          20.50,
+		},
+	}, nil)
+
+	rr := postNCPDP(mockService, ncpdpTransmission(ncpdp.TransactionBilling))
+
+	assert.Contains(t, rr.Body.String(), "AM23"+ncpdpFS+"F6185{"+ncpdpFS+"F720{"+ncpdpFS+"F9205{")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestNCPDP_BillingCostShare(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	mockService.On("SubmitClaim", mock.Anything).Return(&models.ClaimResponse{
+		Status:  models.ClaimStatusPaid,
+		ClaimID: uuid.New(),
+		Pricing: &models.ClaimPricing{
+			Basis:          models.PriceBasisAWP,
+			IngredientCost: 18.50,
+			DispensingFee:  2.00,
+			Total:          20.50,
+		},
+		CostShare: &models.CostShare{
+			Deductible: 5.00,
+			Copay:      10.00,
+			PatientPay: 15.00,
+			PlanPaid:   5.50,
+		},
+	}, nil)
+
+	rr := postNCPDP(mockService, ncpdpTransmission(ncpdp.TransactionBilling))
+
+	assert.Contains(t, rr.Body.String(), "AM23"+ncpdpFS+"F5150{"+ncpdpFS+"F6185{"+ncpdpFS+"F720{"+
+		ncpdpFS+"FH50{"+ncpdpFS+"FI100{"+ncpdpFS+"F955{"+ncpdpFS+"4U{")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestNCPDP_BillingRejected(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	mockService.On("SubmitClaim", mock.Anything).Return(&models.ClaimResponse{
+		Status:  models.ClaimStatusRejected,
+		ClaimID: uuid.New(),
+		Rejects: []models.Reject{
+			{Code: ncpdp.RejectPharmacyNotContracted, Message: "pharmacy 1234567890 is inactive"},
+			{Code: ncpdp.RejectDuplicateClaim, Message: "duplicate of claim"},
+		},
+	}, nil)
+
+	rr := postNCPDP(mockService, ncpdpTransmission(ncpdp.TransactionBilling))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	assert.Contains(t, body, "AM21"+ncpdpFS+"ANR"+ncpdpFS+"FA2"+ncpdpFS+"FB"+ncpdp.RejectPharmacyNotContracted+ncpdpFS+"FB"+ncpdp.RejectDuplicateClaim)
+	assert.NotContains(t, body, "AM23")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestNCPDP_BillingDURRejected(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	mockService.On("SubmitClaim", mock.Anything).Return(&models.ClaimResponse{
+		Status:  models.ClaimStatusRejected,
+		ClaimID: uuid.New(),
+		Rejects: []models.Reject{
+			{Code: ncpdp.RejectRefillTooSoon, Message: "refill too soon"},
+		},
+		DUR: []models.DURAlert{
+			{
+				ReasonCode:       ncpdp.DURReasonEarlyRefill,
+				Hard:             true,
+				Message:          "refill too soon",
+				OtherNDC:         "00002323401",
+				OtherPharmacy:    true,
+				PreviousFillDate: time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC),
+				PreviousQuantity: 30,
+			},
+		},
+	}, nil)
+
+	rr := postNCPDP(mockService, ncpdpTransmission(ncpdp.TransactionBilling))
+
+	body := rr.Body.String()
+	assert.Contains(t, body, ncpdpFS+"FB"+ncpdp.RejectRefillTooSoon)
+	assert.Contains(t, body, "AM24"+ncpdpFS+"J61"+ncpdpFS+"E4"+ncpdp.DURReasonEarlyRefill+ncpdpFS+"FT"+ncpdp.OtherPharmacyOther+
+		ncpdpFS+"FU20240120"+ncpdpFS+"FV30000"+ncpdpFS+"FW"+ncpdp.DURDatabaseProcessor+ncpdpFS+"FYrefill too soon")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestNCPDP_BillingPharmacyNotFound(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	mockService.On("SubmitClaim", mock.Anything).Return(nil, fmt.Errorf("%w: npi 1234567890", models.ErrPharmacyNotFound))
+
+	rr := postNCPDP(mockService, ncpdpTransmission(ncpdp.TransactionBilling))
+
+	assert.Contains(t, rr.Body.String(), ncpdpFS+"FB"+ncpdp.RejectPharmacyNumber)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestNCPDP_BillingEligibilityRejected(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	mockService.On("SubmitClaim", mock.Anything).Return(&models.ClaimResponse{
+		Status:  models.ClaimStatusRejected,
+		ClaimID: uuid.New(),
+		Rejects: []models.Reject{
+			{Code: ncpdp.RejectAfterCoverageTerminated, Message: "coverage for member MEMBER001 terminated 2023-12-31"},
+		},
+	}, nil)
+
+	rr := postNCPDP(mockService, ncpdpTransmission(ncpdp.TransactionBilling))
+
+	assert.Contains(t, rr.Body.String(), "ANR"+ncpdpFS+"FA1"+ncpdpFS+"FB"+ncpdp.RejectAfterCoverageTerminated)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestNCPDP_BillingFormularyRejected(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	mockService.On("SubmitClaim", mock.Anything).Return(&models.ClaimResponse{
+		Status:  models.ClaimStatusRejected,
+		ClaimID: uuid.New(),
+		Rejects: []models.Reject{
+			{Code: ncpdp.RejectPriorAuthRequired, Message: "ndc 00002323401 requires prior authorization on the COMMERCIAL formulary"},
+			{Code: ncpdp.RejectStepTherapyRequired, Message: "ndc 00002323401 requires step therapy on the COMMERCIAL formulary"},
+		},
+	}, nil)
+
+	rr := postNCPDP(mockService, ncpdpTransmission(ncpdp.TransactionBilling))
+
+	assert.Contains(t, rr.Body.String(), "FA2"+ncpdpFS+"FB"+ncpdp.RejectPriorAuthRequired+ncpdpFS+"FB"+ncpdp.RejectStepTherapyRequired)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestNCPDP_BillingMissingCardholder(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	mockService.On("SubmitClaim", mock.Anything).Return(nil, models.NewValidationError("member_id", "member_id is required"))
+
+	rr := postNCPDP(mockService, ncpdpTransmission(ncpdp.TransactionBilling))
+
+	assert.Contains(t, rr.Body.String(), ncpdpFS+"FB"+ncpdp.RejectCardholderID)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestNCPDP_BillingValidationReject(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	mockService.On("SubmitClaim", mock.Anything).Return(nil, models.NewValidationError("ndc", "invalid NDC format"))
+
+	rr := postNCPDP(mockService, ncpdpTransmission(ncpdp.TransactionBilling))
+
+	assert.Contains(t, rr.Body.String(), ncpdpFS+"FB"+ncpdp.RejectProductID)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestNCPDP_ReversalApproved(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	claimID := uuid.New()
+	serviceDate := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	mockService.On("FindClaimForReversal", models.ReversalMatch{
+		NPI:                "1234567890",
+		NDC:                "00002323401",
+		MemberID:           "MEMBER001",
+		PrescriptionNumber: "000000123456",
+		ServiceDate:        serviceDate,
+	}).Return(&models.Claim{ID: claimID}, nil)
+	mockService.On("ReverseClaim", models.ReversalRequest{
+		ClaimID:     claimID,
+		NPI:         "1234567890",
+		NDC:         "00002323401",
+		ServiceDate: "2024-02-01",
+		Source:      models.ReversalSourceNCPDP,
+	}).Return(&models.ReversalResponse{Status: "claim reversed", ClaimID: claimID, ReversalID: uuid.New()}, nil)
+
+	rr := postNCPDP(mockService, ncpdpTransmission(ncpdp.TransactionReversal))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	assert.Equal(t, "D0B21A011234567890     20240201", body[:ncpdp.ResponseHeaderLength])
+	assert.Contains(t, body, "AM21"+ncpdpFS+"ANA")
+
+	mockService.AssertExpectations(t)
+}
+
+func TestNCPDP_ReversalSameDayFills(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	serviceDate := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	match := func(prescriptionNumber string, fillNumber int) models.ReversalMatch {
+		return models.ReversalMatch{
+			NPI:                "1234567890",
+			NDC:                "00002323401",
+			MemberID:           "MEMBER001",
+			PrescriptionNumber: prescriptionNumber,
+			FillNumber:         fillNumber,
+			ServiceDate:        serviceDate,
+		}
+	}
+
+	firstFill := uuid.New()
+	secondFill := uuid.New()
+	otherPrescription := uuid.New()
+	mockService.On("FindClaimForReversal", match("000000123456", 0)).Return(&models.Claim{ID: firstFill}, nil).Maybe()
+	mockService.On("FindClaimForReversal", match("000000123456", 1)).Return(&models.Claim{ID: secondFill}, nil)
+	mockService.On("FindClaimForReversal", match("000000654321", 1)).Return(&models.Claim{ID: otherPrescription}, nil).Maybe()
+	mockService.On("ReverseClaim", mock.MatchedBy(func(request models.ReversalRequest) bool {
+		return request.ClaimID == secondFill
+	})).Return(&models.ReversalResponse{Status: "claim reversed", ClaimID: secondFill, ReversalID: uuid.New()}, nil)
+
+	raw := strings.Replace(ncpdpTransmission(ncpdp.TransactionReversal), ncpdpFS+"D700002323401", ncpdpFS+"D31"+ncpdpFS+"D700002323401", 1)
+	rr := postNCPDP(mockService, raw)
+
+	assert.Contains(t, rr.Body.String(), "AM21"+ncpdpFS+"ANA")
+	assert.Contains(t, rr.Body.String(), secondFill.String())
+
+	mockService.AssertExpectations(t)
+}
+
+func TestNCPDP_ReversalClaimNotFound(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	mockService.On("FindClaimForReversal", mock.Anything).Return(nil, fmt.Errorf("%w: npi 1234567890", models.ErrClaimNotFound))
+
+	rr := postNCPDP(mockService, ncpdpTransmission(ncpdp.TransactionReversal))
+
+	assert.Contains(t, rr.Body.String(), "ANR"+ncpdpFS+"FA1"+ncpdpFS+"FB"+ncpdp.RejectReversalNotProcessed)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestNCPDP_ReversalAmbiguous(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	mockService.On("FindClaimForReversal", mock.Anything).Return(nil, fmt.Errorf("%w: npi 1234567890", models.ErrClaimAmbiguous))
+
+	rr := postNCPDP(mockService, ncpdpTransmission(ncpdp.TransactionReversal))
+
+	assert.Contains(t, rr.Body.String(), "ANR"+ncpdpFS+"FA1"+ncpdpFS+"FB"+ncpdp.RejectReversalNotProcessed)
+
+	mockService.AssertNotCalled(t, "ReverseClaim", mock.Anything)
+	mockService.AssertExpectations(t)
+}
+
+func TestNCPDP_TransmissionRejected(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	raw := strings.Replace(ncpdpTransmission(ncpdp.TransactionBilling), "D0B1", "D0E1", 1)
+	rr := postNCPDP(mockService, raw)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	assert.Equal(t, ncpdp.HeaderStatusRejected, body[5:6])
+	assert.Contains(t, body, "FB"+ncpdp.RejectTransactionNotSupported)
+
+	mockService.AssertNotCalled(t, "SubmitClaim", mock.Anything)
+}
+
+func TestNCPDP_InvalidRequest(t *testing.T) {
+	mockService := &MockNCPDPService{}
+
+	rr := postNCPDP(mockService, "garbage")
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestNCPDP_MethodNotAllowed(t *testing.T) {
+	mockService := &MockNCPDPService{}
+	mux := http.NewServeMux()
+	handlers.NewNCPDPHandler(mockService).RegisterRoutes(mux)
+
+	req := httptest.NewRequest("GET", "/ncpdp", nil)
+	rr := httptest.NewRecorder()
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
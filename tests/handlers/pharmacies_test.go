@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"pharmacyclaims/internal/handlers"
+	"pharmacyclaims/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockPharmacyService struct {
+	mock.Mock
+}
+
+func (m *MockPharmacyService) ValidatePharmacy(request models.PharmacyRequest) error {
+	args := m.Called(request)
+	return args.Error(0)
+}
+
+func (m *MockPharmacyService) ListPharmacies(filter models.PharmacyFilter) ([]models.Pharmacy, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Pharmacy), args.Error(1)
+}
+
+func (m *MockPharmacyService) GetPharmacy(npi string) (*models.Pharmacy, error) {
+	args := m.Called(npi)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Pharmacy), args.Error(1)
+}
+
+func (m *MockPharmacyService) CreatePharmacy(request models.PharmacyRequest) (*models.Pharmacy, error) {
+	args := m.Called(request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Pharmacy), args.Error(1)
+}
+
+func (m *MockPharmacyService) UpdatePharmacy(npi string, request models.PharmacyRequest) (*models.Pharmacy, error) {
+	args := m.Called(npi, request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Pharmacy), args.Error(1)
+}
+
+func (m *MockPharmacyService) DeactivatePharmacy(npi string) (*models.Pharmacy, error) {
+	args := m.Called(npi)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Pharmacy), args.Error(1)
+}
+
+func newPharmacyMux(mockService *MockPharmacyService) *http.ServeMux {
+	mux := http.NewServeMux()
+	handlers.NewPharmacyHandler(mockService).RegisterRoutes(mux)
+	return mux
+}
+
+func TestListPharmacies_Success(t *testing.T) {
+	mockService := &MockPharmacyService{}
+	mux := newPharmacyMux(mockService)
+
+	active := true
+	filter := models.PharmacyFilter{Chain: "health", Active: &active}
+	expected := []models.Pharmacy{{ID: 1, NPI: "1234567890", Chain: "health", Active: true}}
+
+	mockService.On("ListPharmacies", filter).Return(expected, nil)
+
+	req := httptest.NewRequest("GET", "/pharmacies?chain=health&active=true", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response []models.Pharmacy
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, expected, response)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetPharmacy_NotFound(t *testing.T) {
+	mockService := &MockPharmacyService{}
+	mux := newPharmacyMux(mockService)
+
+	mockService.On("GetPharmacy", "9999999999").Return(nil, fmt.Errorf("%w: npi 9999999999", models.ErrPharmacyNotFound))
+
+	req := httptest.NewRequest("GET", "/pharmacies/9999999999", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Pharmacy not found", errorResponse.Error)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCreatePharmacy_Success(t *testing.T) {
+	mockService := &MockPharmacyService{}
+	mux := newPharmacyMux(mockService)
+
+	request := models.PharmacyRequest{NPI: "1234567890", Chain: "saint"}
+	expected := &models.Pharmacy{ID: 21, NPI: "1234567890", Chain: "saint", Active: true}
+
+	mockService.On("ValidatePharmacy", request).Return(nil)
+	mockService.On("CreatePharmacy", request).Return(expected, nil)
+
+	requestBody, _ := json.Marshal(request)
+	req := httptest.NewRequest("POST", "/pharmacies", bytes.NewBuffer(requestBody))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var response models.Pharmacy
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, *expected, response)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCreatePharmacy_ValidationFailed(t *testing.T) {
+	mockService := &MockPharmacyService{}
+	mux := newPharmacyMux(mockService)
+
+	request := models.PharmacyRequest{NPI: "1234567890", Chain: "acme"}
+	mockService.On("ValidatePharmacy", request).Return(models.NewValidationError("chain", "invalid chain: must be one of health, saint, doctor"))
+
+	requestBody, _ := json.Marshal(request)
+	req := httptest.NewRequest("POST", "/pharmacies", bytes.NewBuffer(requestBody))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Validation failed", errorResponse.Error)
+	assert.Equal(t, models.CodeValidationFailed, errorResponse.Code)
+	assert.Equal(t, "chain", errorResponse.Field)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCreatePharmacy_AlreadyExists(t *testing.T) {
+	mockService := &MockPharmacyService{}
+	mux := newPharmacyMux(mockService)
+
+	request := models.PharmacyRequest{NPI: "1234567890", Chain: "health"}
+	mockService.On("ValidatePharmacy", request).Return(nil)
+	mockService.On("CreatePharmacy", request).Return(nil, fmt.Errorf("%w: npi 1234567890", models.ErrPharmacyExists))
+
+	requestBody, _ := json.Marshal(request)
+	req := httptest.NewRequest("POST", "/pharmacies", bytes.NewBuffer(requestBody))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCreatePharmacy_NPINotPharmacy(t *testing.T) {
+	mockService := &MockPharmacyService{}
+	mux := newPharmacyMux(mockService)
+
+	request := models.PharmacyRequest{NPI: "1987654328", Chain: "health"}
+	mockService.On("ValidatePharmacy", request).Return(nil)
+	mockService.On("CreatePharmacy", request).Return(nil, fmt.Errorf("%w: npi 1987654328 is not an organization", models.ErrNPINotPharmacy))
+
+	requestBody, _ := json.Marshal(request)
+	req := httptest.NewRequest("POST", "/pharmacies", bytes.NewBuffer(requestBody))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, models.CodeNPINotPharmacy, errorResponse.Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdatePharmacy_Success(t *testing.T) {
+	mockService := &MockPharmacyService{}
+	mux := newPharmacyMux(mockService)
+
+	active := false
+	request := models.PharmacyRequest{NPI: "1234567890", Chain: "doctor", Active: &active}
+	expected := &models.Pharmacy{ID: 1, NPI: "1234567890", Chain: "doctor", Active: false}
+
+	mockService.On("ValidatePharmacy", request).Return(nil)
+	mockService.On("UpdatePharmacy", "1234567890", request).Return(expected, nil)
+
+	req := httptest.NewRequest("PUT", "/pharmacies/1234567890", strings.NewReader(`{"chain": "doctor", "active": false}`))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response models.Pharmacy
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, *expected, response)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdatePharmacy_NPIMismatch(t *testing.T) {
+	mockService := &MockPharmacyService{}
+	mux := newPharmacyMux(mockService)
+
+	req := httptest.NewRequest("PUT", "/pharmacies/1234567890", strings.NewReader(`{"npi": "0987654321", "chain": "doctor"}`))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestDeactivatePharmacy_Success(t *testing.T) {
+	mockService := &MockPharmacyService{}
+	mux := newPharmacyMux(mockService)
+
+	expected := &models.Pharmacy{ID: 1, NPI: "1234567890", Chain: "health", Active: false}
+	mockService.On("DeactivatePharmacy", "1234567890").Return(expected, nil)
+
+	req := httptest.NewRequest("DELETE", "/pharmacies/1234567890", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response models.Pharmacy
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.False(t, response.Active)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestPharmacies_MethodNotAllowed(t *testing.T) {
+	mockService := &MockPharmacyService{}
+	mux := newPharmacyMux(mockService)
+
+	req := httptest.NewRequest("PATCH", "/pharmacies", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
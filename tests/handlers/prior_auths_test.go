@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"pharmacyclaims/internal/handlers"
+	"pharmacyclaims/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockPriorAuthService struct {
+	mock.Mock
+}
+
+func (m *MockPriorAuthService) ValidatePriorAuth(request models.PriorAuthRequest) error {
+	args := m.Called(request)
+	return args.Error(0)
+}
+
+func (m *MockPriorAuthService) GetPriorAuth(id int) (*models.PriorAuth, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PriorAuth), args.Error(1)
+}
+
+func (m *MockPriorAuthService) CreatePriorAuth(request models.PriorAuthRequest) (*models.PriorAuth, error) {
+	args := m.Called(request)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PriorAuth), args.Error(1)
+}
+
+func newPriorAuthMux(mockService *MockPriorAuthService) *http.ServeMux {
+	mux := http.NewServeMux()
+	handlers.NewPriorAuthHandler(mockService).RegisterRoutes(mux)
+	return mux
+}
+
+func TestCreatePriorAuth_Success(t *testing.T) {
+	mockService := &MockPriorAuthService{}
+	mux := newPriorAuthMux(mockService)
+
+	request := models.PriorAuthRequest{
+		MemberID:         "MEMBER001",
+		NDC:              "00002323401",
+		ApprovedQuantity: 90,
+		EffectiveFrom:    "2024-01-01",
+		EffectiveTo:      "2024-06-30",
+	}
+	expected := &models.PriorAuth{
+		ID:                1,
+		MemberID:          "MEMBER001",
+		NDC:               "00002323401",
+		ApprovedQuantity:  90,
+		RemainingQuantity: 90,
+		EffectiveFrom:     time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		EffectiveTo:       time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC),
+		Status:            models.PriorAuthStatusApproved,
+		CreatedAt:         time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	mockService.On("ValidatePriorAuth", request).Return(nil)
+	mockService.On("CreatePriorAuth", request).Return(expected, nil)
+
+	requestBody, _ := json.Marshal(request)
+	req := httptest.NewRequest("POST", "/prior-auths", bytes.NewBuffer(requestBody))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	var response models.PriorAuth
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, *expected, response)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCreatePriorAuth_ValidationFailed(t *testing.T) {
+	mockService := &MockPriorAuthService{}
+	mux := newPriorAuthMux(mockService)
+
+	request := models.PriorAuthRequest{MemberID: "MEMBER001", ApprovedQuantity: 90, EffectiveFrom: "2024-01-01", EffectiveTo: "2024-06-30"}
+	mockService.On("ValidatePriorAuth", request).Return(models.NewValidationError("ndc", "invalid prior authorization: set either ndc or drug_class"))
+
+	requestBody, _ := json.Marshal(request)
+	req := httptest.NewRequest("POST", "/prior-auths", bytes.NewBuffer(requestBody))
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "ndc", errorResponse.Field)
+
+	mockService.AssertNotCalled(t, "CreatePriorAuth", mock.Anything)
+}
+
+func TestGetPriorAuth_NotFound(t *testing.T) {
+	mockService := &MockPriorAuthService{}
+	mux := newPriorAuthMux(mockService)
+
+	mockService.On("GetPriorAuth", 99).Return(nil, fmt.Errorf("%w: id 99", models.ErrPriorAuthNotFound))
+
+	req := httptest.NewRequest("GET", "/prior-auths/99", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, models.CodePriorAuthNotFound, errorResponse.Code)
+
+	mockService.AssertExpectations(t)
+}
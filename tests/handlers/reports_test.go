@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"pharmacyclaims/internal/handlers"
+	"pharmacyclaims/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockReportsService struct {
+	mock.Mock
+}
+
+func (m *MockReportsService) ValidateMetricsFilter(filter models.MetricsFilter) error {
+	args := m.Called(filter)
+	return args.Error(0)
+}
+
+func (m *MockReportsService) GetClaimMetrics(filter models.MetricsFilter) ([]models.ClaimMetrics, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ClaimMetrics), args.Error(1)
+}
+
+func TestReportsHandler_RegisterRoutes(t *testing.T) {
+	mockService := &MockReportsService{}
+	mux := http.NewServeMux()
+	handlers.NewReportsHandler(mockService).RegisterRoutes(mux)
+
+	req := httptest.NewRequest("POST", "/reports/metrics", nil)
+	rr := httptest.NewRecorder()
+
+	mux.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
+
+func TestGetClaimMetrics_Success(t *testing.T) {
+	mockService := &MockReportsService{}
+	handler := handlers.NewReportsHandler(mockService)
+
+	filter := models.MetricsFilter{NPI: "1234567890"}
+	expected := []models.ClaimMetrics{
+		{NPI: "1234567890", NDC: "00002323401", Fills: 3, Reverted: 1, AvgPrice: 2.5, TotalPrice: 50},
+	}
+
+	mockService.On("ValidateMetricsFilter", filter).Return(nil)
+	mockService.On("GetClaimMetrics", filter).Return(expected, nil)
+
+	req := httptest.NewRequest("GET", "/reports/metrics?npi=1234567890", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetClaimMetrics(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var response []models.ClaimMetrics
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, expected, response)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetClaimMetrics_ValidationFailed(t *testing.T) {
+	mockService := &MockReportsService{}
+	handler := handlers.NewReportsHandler(mockService)
+
+	filter := models.MetricsFilter{NDC: "abc"}
+	mockService.On("ValidateMetricsFilter", filter).Return(fmt.Errorf("invalid NDC format: must be 9-11 digits"))
+
+	req := httptest.NewRequest("GET", "/reports/metrics?ndc=abc", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetClaimMetrics(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Validation failed", errorResponse.Error)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetClaimMetrics_InternalServerError(t *testing.T) {
+	mockService := &MockReportsService{}
+	handler := handlers.NewReportsHandler(mockService)
+
+	filter := models.MetricsFilter{}
+	mockService.On("ValidateMetricsFilter", filter).Return(nil)
+	mockService.On("GetClaimMetrics", filter).Return(nil, fmt.Errorf("database connection failed"))
+
+	req := httptest.NewRequest("GET", "/reports/metrics", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetClaimMetrics(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Failed to get claim metrics", errorResponse.Error)
+	assert.Equal(t, "database connection failed", errorResponse.Message)
+
+	mockService.AssertExpectations(t)
+}
+
+func (m *MockReportsService) ValidateRecommendationFilter(filter models.RecommendationFilter) error {
+	args := m.Called(filter)
+	return args.Error(0)
+}
+
+func (m *MockReportsService) GetChainRecommendations(filter models.RecommendationFilter) ([]models.ChainRecommendation, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ChainRecommendation), args.Error(1)
+}
+
+func TestGetChainRecommendations_Success(t *testing.T) {
+	mockService := &MockReportsService{}
+	handler := handlers.NewReportsHandler(mockService)
+
+	filter := models.RecommendationFilter{
+		NDCs:  []string{"00002323401", "00054027225"},
+		Limit: 2,
+	}
+	expected := []models.ChainRecommendation{
+		{
+			NDC: "00002323401",
+			Chains: []models.ChainPrice{
+				{Name: "saint", AvgPrice: 1.25},
+				{Name: "health", AvgPrice: 1.4},
+			},
+		},
+	}
+
+	mockService.On("ValidateRecommendationFilter", filter).Return(nil)
+	mockService.On("GetChainRecommendations", filter).Return(expected, nil)
+
+	req := httptest.NewRequest("GET", "/recommendations/chains?ndc=00002323401,00054027225&limit=2", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetChainRecommendations(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response []models.ChainRecommendation
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, expected, response)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetChainRecommendations_InvalidLimit(t *testing.T) {
+	mockService := &MockReportsService{}
+	handler := handlers.NewReportsHandler(mockService)
+
+	req := httptest.NewRequest("GET", "/recommendations/chains?limit=two", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetChainRecommendations(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Invalid limit", errorResponse.Error)
+}
+
+func TestGetChainRecommendations_MethodNotAllowed(t *testing.T) {
+	mockService := &MockReportsService{}
+	handler := handlers.NewReportsHandler(mockService)
+
+	req := httptest.NewRequest("POST", "/recommendations/chains", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetChainRecommendations(rr, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
+
+func (m *MockReportsService) ValidateQuantityFilter(filter models.QuantityFilter) error {
+	args := m.Called(filter)
+	return args.Error(0)
+}
+
+func (m *MockReportsService) GetMostPrescribedQuantities(filter models.QuantityFilter) ([]models.QuantityReport, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.QuantityReport), args.Error(1)
+}
+
+func TestGetMostPrescribedQuantities_Success(t *testing.T) {
+	mockService := &MockReportsService{}
+	handler := handlers.NewReportsHandler(mockService)
+
+	filter := models.QuantityFilter{NDCs: []string{"00002323401"}, Limit: 3}
+	expected := []models.QuantityReport{
+		{
+			NDC: "00002323401",
+			Quantities: []models.QuantityCount{
+				{Quantity: 30, Count: 12},
+				{Quantity: 90, Count: 7},
+				{Quantity: 60, Count: 2},
+			},
+		},
+	}
+
+	mockService.On("ValidateQuantityFilter", filter).Return(nil)
+	mockService.On("GetMostPrescribedQuantities", filter).Return(expected, nil)
+
+	req := httptest.NewRequest("GET", "/reports/quantities?ndc=00002323401&limit=3", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetMostPrescribedQuantities(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	var response []models.QuantityReport
+	err := json.Unmarshal(rr.Body.Bytes(), &response)
+	require.NoError(t, err)
+	assert.Equal(t, expected, response)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestGetMostPrescribedQuantities_ValidationFailed(t *testing.T) {
+	mockService := &MockReportsService{}
+	handler := handlers.NewReportsHandler(mockService)
+
+	filter := models.QuantityFilter{Limit: 500}
+	mockService.On("ValidateQuantityFilter", filter).Return(fmt.Errorf("invalid limit: must be between 1 and 50"))
+
+	req := httptest.NewRequest("GET", "/reports/quantities?limit=500", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetMostPrescribedQuantities(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var errorResponse models.ErrorResponse
+	err := json.Unmarshal(rr.Body.Bytes(), &errorResponse)
+	require.NoError(t, err)
+	assert.Equal(t, "Validation failed", errorResponse.Error)
+	assert.Equal(t, "invalid limit: must be between 1 and 50", errorResponse.Message)
+
+	mockService.AssertExpectations(t)
+}
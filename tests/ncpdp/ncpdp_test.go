@@ -0,0 +1,242 @@
+package ncpdp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/ncpdp"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	ss = "\x1e"
+	gs = "\x1d"
+	fs = "\x1c"
+)
+
+func header(transactionCode string, count string) string {
+	return "610020" + "D0" + transactionCode + "PCN1234567" + count + "01" + "1234567890     " + "20240201" + "VENDOR0001"
+}
+
+func billingRequest() string {
+	return header("B1", "1") +
+		ss + fs + "AM04" + fs + "C2MEMBER001" + fs + "C1GROUP01" +
+		ss + fs + "AM01" + fs + "CYPAT001" + fs + "C419800101" + fs + "C51" + fs + "CAJANE" + fs + "CBDOE" +
+		gs +
+		ss + fs + "AM07" + fs + "EM1" + fs + "D2000000123456" + fs + "E103" + fs + "D700002323401" + fs + "E730000" + fs + "D31" + fs + "D530" + fs + "D81" + fs + "DE20240115" +
+		ss + fs + "AM11" + fs + "D9225{" + fs + "DC25{" + fs + "DU250{" +
+		ss + fs + "AM03" + fs + "EZ01" + fs + "DB1987654321"
+}
+
+func TestParseRequest_Billing(t *testing.T) {
+	request, err := ncpdp.ParseRequest([]byte(billingRequest()))
+	require.NoError(t, err)
+
+	assert.Equal(t, "610020", request.Header.BIN)
+	assert.Equal(t, ncpdp.TransactionBilling, request.Header.TransactionCode)
+	assert.Equal(t, 1, request.Header.TransactionCount)
+	assert.Equal(t, "1234567890", request.Header.ServiceProviderID)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), request.Header.DateOfService)
+
+	require.NotNil(t, request.Patient)
+	assert.Equal(t, "JANE", request.Patient.FirstName)
+	assert.Equal(t, "19800101", request.Patient.DateOfBirth)
+	require.NotNil(t, request.Insurance)
+	assert.Equal(t, "MEMBER001", request.Insurance.CardholderID)
+
+	require.Len(t, request.Transactions, 1)
+	transaction := request.Transactions[0]
+	assert.Equal(t, "000000123456", transaction.Claim.PrescriptionNumber)
+	assert.Equal(t, "00002323401", transaction.Claim.ProductID)
+	assert.Equal(t, 30.0, transaction.Claim.QuantityDispensed)
+	assert.Equal(t, 30, transaction.Claim.DaysSupply)
+	assert.Equal(t, 1, transaction.Claim.FillNumber)
+	assert.Equal(t, "1", transaction.Claim.DispenseAsWritten)
+	assert.Equal(t, time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), transaction.Claim.DatePrescriptionWritten)
+	require.NotNil(t, transaction.Pricing)
+	assert.InDelta(t, 22.50, transaction.Pricing.IngredientCost, 0.001)
+	assert.InDelta(t, 2.50, transaction.Pricing.DispensingFee, 0.001)
+	assert.InDelta(t, 25.00, transaction.Pricing.GrossAmountDue, 0.001)
+	require.NotNil(t, transaction.Prescriber)
+	assert.Equal(t, "1987654321", transaction.Prescriber.ID)
+
+	claimRequest := transaction.ClaimRequest(request.Header, request.Insurance)
+	assert.Equal(t, models.ClaimRequest{
+		NDC:                "00002323401",
+		Quantity:           30,
+		DaysSupply:         30,
+		DAWCode:            "1",
+		FillNumber:         1,
+		PrescriptionNumber: "000000123456",
+		DateWritten:        "2024-01-15",
+		PrescriberNPI:      "1987654321",
+		NPI:                "1234567890",
+		Price:              25,
+		MemberID:           "MEMBER001",
+		ServiceDate:        "2024-02-01",
+	}, claimRequest)
+}
+
+func TestParseRequest_ReversalMapping(t *testing.T) {
+	raw := header("B2", "1") +
+		ss + fs + "AM04" + fs + "C2MEMBER001" +
+		gs + ss + fs + "AM07" + fs + "EM1" + fs + "D2000000123456" + fs + "E103" + fs + "D700002323401" + fs + "D32"
+
+	request, err := ncpdp.ParseRequest([]byte(raw))
+	require.NoError(t, err)
+	require.Len(t, request.Transactions, 1)
+
+	match := request.Transactions[0].ReversalMatch(request.Header, request.Insurance)
+	assert.Equal(t, models.ReversalMatch{
+		NPI:                "1234567890",
+		NDC:                "00002323401",
+		MemberID:           "MEMBER001",
+		PrescriptionNumber: "000000123456",
+		FillNumber:         2,
+		ServiceDate:        time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}, match)
+
+	claimID := uuid.New()
+	reversal := request.Transactions[0].ReversalRequest(request.Header, claimID)
+	assert.Equal(t, claimID, reversal.ClaimID)
+	assert.Equal(t, "1234567890", reversal.NPI)
+	assert.Equal(t, "00002323401", reversal.NDC)
+	assert.Equal(t, "2024-02-01", reversal.ServiceDate)
+	assert.Equal(t, models.ReversalSourceNCPDP, reversal.Source)
+}
+
+func TestParseRequest_NormalizesProductID(t *testing.T) {
+	raw := header("B2", "1") +
+		gs + ss + fs + "AM07" + fs + "EM1" + fs + "D2000000123456" + fs + "E103" + fs + "D70002-3234-01"
+
+	request, err := ncpdp.ParseRequest([]byte(raw))
+	require.NoError(t, err)
+	require.Len(t, request.Transactions, 1)
+
+	transaction := request.Transactions[0]
+	assert.Equal(t, "00002323401", transaction.ClaimRequest(request.Header, request.Insurance).NDC)
+	assert.Equal(t, "00002323401", transaction.ReversalMatch(request.Header, request.Insurance).NDC)
+	assert.Equal(t, "00002323401", transaction.ReversalRequest(request.Header, uuid.New()).NDC)
+}
+
+func TestParseRequest_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		raw        string
+		rejectCode string
+	}{
+		{
+			name:       "Unsupported version",
+			raw:        strings.Replace(header("B1", "1"), "D0", "51", 1),
+			rejectCode: ncpdp.RejectVersionNotSupported,
+		},
+		{
+			name:       "Unsupported transaction code",
+			raw:        header("E1", "1"),
+			rejectCode: ncpdp.RejectTransactionNotSupported,
+		},
+		{
+			name:       "Transaction count mismatch",
+			raw:        header("B1", "2") + gs + ss + fs + "AM07" + fs + "E103" + fs + "D700002323401",
+			rejectCode: ncpdp.RejectTransactionCount,
+		},
+		{
+			name:       "Missing claim segment",
+			raw:        header("B1", "1") + gs + ss + fs + "AM11" + fs + "DU250{",
+			rejectCode: ncpdp.RejectClaimNotProcessed,
+		},
+		{
+			name:       "Invalid quantity",
+			raw:        header("B1", "1") + gs + ss + fs + "AM07" + fs + "E103" + fs + "D700002323401" + fs + "E7ABC",
+			rejectCode: ncpdp.RejectQuantityDispensed,
+		},
+		{
+			name:       "Invalid date prescription written",
+			raw:        header("B1", "1") + gs + ss + fs + "AM07" + fs + "E103" + fs + "D700002323401" + fs + "DE20241301",
+			rejectCode: ncpdp.RejectDatePrescriptionWritten,
+		},
+		{
+			name:       "Invalid gross amount due",
+			raw:        header("B1", "1") + gs + ss + fs + "AM07" + fs + "E103" + fs + "D700002323401" + ss + fs + "AM11" + fs + "DU25X",
+			rejectCode: ncpdp.RejectGrossAmountDue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := ncpdp.ParseRequest([]byte(tt.raw))
+			require.Error(t, err)
+			require.NotNil(t, request)
+
+			var parseErr *ncpdp.ParseError
+			require.ErrorAs(t, err, &parseErr)
+			assert.Equal(t, tt.rejectCode, parseErr.RejectCode)
+		})
+	}
+}
+
+func TestParseRequest_ShortHeader(t *testing.T) {
+	request, err := ncpdp.ParseRequest([]byte("610020D0B1"))
+	assert.Error(t, err)
+	assert.Nil(t, request)
+}
+
+func TestOverpunch(t *testing.T) {
+	tests := []struct {
+		raw    string
+		amount float64
+	}{
+		{"250{", 25.00},
+		{"123E", 12.35},
+		{"123N", -12.35},
+		{"1}", -0.10},
+		{"1234", 12.34},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			amount, err := ncpdp.ParseOverpunch(tt.raw, 2)
+			require.NoError(t, err)
+			assert.InDelta(t, tt.amount, amount, 0.0001)
+		})
+	}
+
+	assert.Equal(t, "250{", ncpdp.FormatOverpunch(25.00, 2))
+	assert.Equal(t, "123N", ncpdp.FormatOverpunch(-12.35, 2))
+
+	_, err := ncpdp.ParseOverpunch("12X", 2)
+	assert.Error(t, err)
+}
+
+func TestEncodeResponse(t *testing.T) {
+	request, err := ncpdp.ParseRequest([]byte(billingRequest()))
+	require.NoError(t, err)
+
+	paid := 25.00
+	response := ncpdp.NewResponse(request.Header)
+	response.Transactions = []ncpdp.TransactionResponse{
+		{
+			Status:                   ncpdp.TransactionStatusPaid,
+			PrescriptionRefQualifier: "1",
+			PrescriptionNumber:       "000000123456",
+			TotalAmountPaid:          &paid,
+		},
+		{
+			Status:      ncpdp.TransactionStatusRejected,
+			RejectCodes: []string{ncpdp.RejectDuplicateClaim},
+			Message:     "duplicate claim",
+		},
+	}
+
+	encoded := string(ncpdp.EncodeResponse(response))
+
+	require.GreaterOrEqual(t, len(encoded), ncpdp.ResponseHeaderLength)
+	assert.Equal(t, "D0B11A011234567890     20240201", encoded[:ncpdp.ResponseHeaderLength])
+	assert.Contains(t, encoded, gs+ss+fs+"AM21"+fs+"ANP"+ss+fs+"AM22"+fs+"EM1"+fs+"D2000000123456"+ss+fs+"AM23"+fs+"F9250{")
+	assert.Contains(t, encoded, gs+ss+fs+"AM21"+fs+"ANR"+fs+"FA1"+fs+"FB83"+fs+"FQduplicate claim")
+}
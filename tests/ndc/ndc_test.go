@@ -0,0 +1,62 @@
+package ndc
+
+import (
+	"testing"
+
+	"pharmacyclaims/internal/ndc"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		value      string
+		canonical  string
+		hyphenated string
+	}{
+		{"Eleven digits", "00002323401", "00002323401", "00002-3234-01"},
+		{"4-4-2", "0002-3234-01", "00002323401", "00002-3234-01"},
+		{"5-3-2", "50242-040-62", "50242004062", "50242-0040-62"},
+		{"5-4-1", "60575-4112-1", "60575411201", "60575-4112-01"},
+		{"5-4-2", "00015-0668-12", "00015066812", "00015-0668-12"},
+		{"Surrounding spaces", " 0002-3234-01 ", "00002323401", "00002-3234-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := ndc.Parse(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.canonical, code.String())
+			assert.Equal(t, tt.hyphenated, code.Hyphenated())
+			assert.Equal(t, tt.canonical, ndc.Normalize(tt.value))
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		err   error
+	}{
+		{"Empty", "", ndc.ErrFormat},
+		{"Ten digits without hyphens", "0002323401", ndc.ErrFormat},
+		{"Nine digits", "000232340", ndc.ErrFormat},
+		{"Twelve digits", "000023234011", ndc.ErrFormat},
+		{"Unknown configuration", "002-3234-01", ndc.ErrFormat},
+		{"Two segments", "00002-323401", ndc.ErrFormat},
+		{"Empty segment", "00002--01", ndc.ErrFormat},
+		{"Letters", "0000232340A", ndc.ErrNotNumeric},
+		{"Signed", "+0002323401", ndc.ErrNotNumeric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ndc.Parse(tt.value)
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.value, ndc.Normalize(tt.value))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package nppes
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/nppes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidNPI(t *testing.T) {
+	tests := []struct {
+		npi   string
+		valid bool
+	}{
+		{"1234567893", true},
+		{"1987654328", true},
+		{"0000000006", true},
+		{"1234567890", false},
+		{"1234567839", false},
+		{"123456789", false},
+		{"+234567893", false},
+		{"12345678A3", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.npi, func(t *testing.T) {
+			assert.Equal(t, tt.valid, nppes.ValidNPI(tt.npi))
+		})
+	}
+
+	assert.Equal(t, 3, nppes.CheckDigit("123456789"))
+}
+
+func TestReader(t *testing.T) {
+	raw := `"NPI","Entity Type Code","Provider Organization Name (Legal Business Name)","Provider Last Name (Legal Name)","Provider First Name","NPI Deactivation Date","NPI Reactivation Date","Healthcare Provider Taxonomy Code_1","Healthcare Provider Taxonomy Code_2"
+"1234567893","2","HEALTH PHARMACY","","","","","3336C0003X","3336M0002X"
+"1987654328","1","","DOE","JOHN","","","207Q00000X",""
+"1234567890","2","BAD CHECK DIGIT","","","","","3336C0003X",""
+"1456789019","","","","","05/01/2023","","",""
+"1333333338","2","REACTIVATED PHARMACY","","","05/01/2023","06/01/2023","3336H0001X",""
+`
+
+	reader, err := nppes.NewReader(strings.NewReader(raw))
+	require.NoError(t, err)
+
+	record, err := reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, &models.NPIRecord{
+		NPI:           "1234567893",
+		EntityType:    models.NPIEntityTypeOrganization,
+		Name:          "HEALTH PHARMACY",
+		TaxonomyCodes: []string{"3336C0003X", "3336M0002X"},
+	}, record)
+	assert.True(t, nppes.IsPharmacyTaxonomy(record.TaxonomyCodes[0]))
+
+	record, err = reader.Read()
+	require.NoError(t, err)
+	assert.Equal(t, models.NPIEntityTypeIndividual, record.EntityType)
+	assert.Equal(t, "JOHN DOE", record.Name)
+	assert.False(t, nppes.IsPharmacyTaxonomy(record.TaxonomyCodes[0]))
+
+	_, err = reader.Read()
+	assert.ErrorIs(t, err, nppes.ErrInvalidRecord)
+
+	record, err = reader.Read()
+	require.NoError(t, err)
+	require.NotNil(t, record.DeactivationDate)
+	assert.Equal(t, time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), *record.DeactivationDate)
+
+	record, err = reader.Read()
+	require.NoError(t, err)
+	assert.Nil(t, record.DeactivationDate)
+
+	_, err = reader.Read()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReader_MissingColumn(t *testing.T) {
+	_, err := nppes.NewReader(strings.NewReader(`"NPI","Provider Organization Name (Legal Business Name)"` + "\n"))
+	assert.Error(t, err)
+}
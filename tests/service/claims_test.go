@@ -0,0 +1,556 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"pharmacyclaims/internal/core"
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/repository"
+	"pharmacyclaims/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type MockClaimsRepository struct {
+	mock.Mock
+}
+
+func (m *MockClaimsRepository) GetPharmacyByNPI(npi string) (*models.Pharmacy, error) {
+	args := m.Called(npi)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Pharmacy), args.Error(1)
+}
+
+func (m *MockClaimsRepository) GetMember(memberID string) (*models.Member, error) {
+	args := m.Called(memberID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Member), args.Error(1)
+}
+
+func (m *MockClaimsRepository) GetFormularyEntry(planID, ndc string) (*models.FormularyEntry, error) {
+	args := m.Called(planID, ndc)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.FormularyEntry), args.Error(1)
+}
+
+func (m *MockClaimsRepository) GetBenefitPlan(planID string) (*models.BenefitPlan, error) {
+	args := m.Called(planID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BenefitPlan), args.Error(1)
+}
+
+func (m *MockClaimsRepository) GetDrugPrice(ndc string, serviceDate time.Time) (*models.DrugPrice, error) {
+	args := m.Called(ndc, serviceDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.DrugPrice), args.Error(1)
+}
+
+func (m *MockClaimsRepository) GetDrugClasses(ndcs []string) (map[string]string, error) {
+	args := m.Called(ndcs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockClaimsRepository) ListDrugInteractions(drugClass string) ([]models.DrugInteraction, error) {
+	args := m.Called(drugClass)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DrugInteraction), args.Error(1)
+}
+
+func (m *MockClaimsRepository) FindPriorAuth(claim *models.Claim, drugClass string) (*models.PriorAuth, error) {
+	args := m.Called(claim, drugClass)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PriorAuth), args.Error(1)
+}
+
+func (m *MockClaimsRepository) FindDuplicateClaim(claim *models.Claim, window time.Duration) (*models.Claim, error) {
+	args := m.Called(claim, window)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Claim), args.Error(1)
+}
+
+func (m *MockClaimsRepository) FindMemberClaimHistory(claim *models.Claim, since time.Time) ([]models.Claim, error) {
+	args := m.Called(claim, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Claim), args.Error(1)
+}
+
+func (m *MockClaimsRepository) FindClaimForReversal(match models.ReversalMatch) (*models.Claim, error) {
+	args := m.Called(match)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Claim), args.Error(1)
+}
+
+func (m *MockClaimsRepository) CreateClaim(claim *models.Claim, costShare repository.CostShareFunc) error {
+	args := m.Called(claim, costShare)
+	return args.Error(0)
+}
+
+func (m *MockClaimsRepository) CreateClaimWithIdempotencyKey(claim *models.Claim, record *models.IdempotencyRecord, costShare repository.CostShareFunc) error {
+	args := m.Called(claim, record, costShare)
+	return args.Error(0)
+}
+
+func (m *MockClaimsRepository) GetIdempotencyRecord(key string, now time.Time) (*models.IdempotencyRecord, error) {
+	args := m.Called(key, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.IdempotencyRecord), args.Error(1)
+}
+
+func (m *MockClaimsRepository) DeleteExpiredIdempotencyKeys(now time.Time) (int64, error) {
+	args := m.Called(now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockClaimsRepository) GetClaimByID(id uuid.UUID) (*models.Claim, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Claim), args.Error(1)
+}
+
+func (m *MockClaimsRepository) GetClaimDetailsByID(id uuid.UUID) (*models.ClaimDetails, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ClaimDetails), args.Error(1)
+}
+
+func (m *MockClaimsRepository) SearchClaims(filter models.ClaimSearchFilter) ([]models.ClaimDetails, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ClaimDetails), args.Error(1)
+}
+
+func (m *MockClaimsRepository) ReverseClaim(reversal *models.Reversal) error {
+	args := m.Called(reversal)
+	return args.Error(0)
+}
+
+func (m *MockClaimsRepository) RebillClaim(reversal *models.Reversal, claim *models.Claim, costShare repository.CostShareFunc) error {
+	args := m.Called(reversal, claim, costShare)
+	return args.Error(0)
+}
+
+func (m *MockClaimsRepository) GetContractForChain(chain string, serviceDate time.Time) (*models.Contract, error) {
+	args := m.Called(chain, serviceDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Contract), args.Error(1)
+}
+
+func newClaimsService(t *testing.T, repo *MockClaimsRepository, policy service.ClaimsPolicy) *service.ClaimsService {
+	return service.NewClaimsServiceWithPolicy(repo, core.NewLogger(t.TempDir()), policy)
+}
+
+func TestReverseClaim_ReversalWindow(t *testing.T) {
+	tests := []struct {
+		name     string
+		window   time.Duration
+		age      time.Duration
+		role     string
+		override bool
+		err      error
+	}{
+		{"inside the window", 90 * 24 * time.Hour, 30 * 24 * time.Hour, "", false, nil},
+		{"outside the window", 90 * 24 * time.Hour, 120 * 24 * time.Hour, "", false, models.ErrReversalWindow},
+		{"override role", 90 * 24 * time.Hour, 120 * 24 * time.Hour, "supervisor", true, nil},
+		{"wrong role", 90 * 24 * time.Hour, 120 * 24 * time.Hour, "technician", false, models.ErrReversalWindow},
+		{"window disabled", 0, 3 * 365 * 24 * time.Hour, "", false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockClaimsRepository{}
+			policy := service.DefaultClaimsPolicy()
+			policy.ReversalWindow = tt.window
+			policy.ReversalOverrideRole = "supervisor"
+			claimsService := newClaimsService(t, repo, policy)
+
+			claim := &models.Claim{
+				ID:        uuid.New(),
+				NPI:       "1234567890",
+				Status:    models.ClaimStatusPaid,
+				Timestamp: models.CustomTime{Time: time.Now().Add(-tt.age)},
+			}
+			repo.On("GetClaimByID", claim.ID).Return(claim, nil)
+			if tt.err == nil {
+				repo.On("ReverseClaim", mock.MatchedBy(func(reversal *models.Reversal) bool {
+					return reversal.ClaimID == claim.ID && reversal.WindowOverride == tt.override
+				})).Return(nil)
+				repo.On("GetPharmacyByNPI", claim.NPI).Return(&models.Pharmacy{NPI: claim.NPI, Chain: "health"}, nil)
+			}
+
+			response, err := claimsService.ReverseClaim(models.ReversalRequest{ClaimID: claim.ID, NPI: claim.NPI, Role: tt.role})
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				repo.AssertNotCalled(t, "ReverseClaim", mock.Anything)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.override, response.WindowOverride)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+const (
+	testNPI      = "1234567890"
+	testMemberID = "M1001"
+	testPlanID   = "PLAN1"
+	testNDC      = "00002323401"
+)
+
+func claimRequest() models.ClaimRequest {
+	return models.ClaimRequest{
+		NDC:         testNDC,
+		NPI:         testNPI,
+		Quantity:    30,
+		DaysSupply:  30,
+		Price:       25,
+		MemberID:    testMemberID,
+		ServiceDate: "2026-03-02",
+	}
+}
+
+func mockClaimLookups(repo *MockClaimsRepository, entry *models.FormularyEntry) {
+	repo.On("GetPharmacyByNPI", testNPI).Return(&models.Pharmacy{NPI: testNPI, Chain: "health", Active: true}, nil)
+	repo.On("GetMember", testMemberID).Return(&models.Member{
+		MemberID:      testMemberID,
+		PlanID:        testPlanID,
+		EffectiveDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, nil)
+	repo.On("GetFormularyEntry", testPlanID, testNDC).Return(entry, nil)
+}
+
+func mockClaimPricing(repo *MockClaimsRepository) {
+	repo.On("GetContractForChain", "health", mock.Anything).Return(nil, models.ErrContractNotFound)
+	repo.On("GetDrugPrice", testNDC, mock.Anything).Return(nil, models.ErrDrugPriceNotFound)
+}
+
+func TestSubmitClaim_StepTherapy(t *testing.T) {
+	priorAuth := &models.PriorAuth{ID: 7, MemberID: testMemberID, DrugClass: "statin", Status: "approved"}
+
+	tests := []struct {
+		name              string
+		priorAuthRequired bool
+		priorAuth         *models.PriorAuth
+		history           []models.Claim
+		historyClasses    map[string]string
+		status            string
+		priorAuthID       *int
+	}{
+		{"prior auth", false, priorAuth, nil, nil, models.ClaimStatusPaid, &priorAuth.ID},
+		{"prior auth required", true, priorAuth, nil, nil, models.ClaimStatusPaid, &priorAuth.ID},
+		{"same class history", false, nil, []models.Claim{{NDC: "00071015523"}}, map[string]string{"00071015523": "statin"}, models.ClaimStatusPaid, nil},
+		{"other class history", false, nil, []models.Claim{{NDC: "00093505601"}}, map[string]string{"00093505601": "ace_inhibitor"}, models.ClaimStatusRejected, nil},
+		{"no history", false, nil, []models.Claim{}, nil, models.ClaimStatusRejected, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockClaimsRepository{}
+			policy := service.DefaultClaimsPolicy()
+			policy.DuplicateWindow = 0
+			policy.DURLookback = 0
+			claimsService := newClaimsService(t, repo, policy)
+
+			mockClaimLookups(repo, &models.FormularyEntry{
+				PlanID:              testPlanID,
+				NDC:                 testNDC,
+				Tier:                2,
+				PriorAuthRequired:   tt.priorAuthRequired,
+				StepTherapyRequired: true,
+			})
+			mockClaimPricing(repo)
+			repo.On("GetDrugClasses", []string{testNDC}).Return(map[string]string{testNDC: "statin"}, nil)
+			if tt.priorAuth != nil {
+				repo.On("FindPriorAuth", mock.Anything, "statin").Return(tt.priorAuth, nil).Once()
+			} else {
+				repo.On("FindPriorAuth", mock.Anything, "statin").Return(nil, models.ErrPriorAuthNotFound).Once()
+				repo.On("FindMemberClaimHistory", mock.Anything, time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)).Return(tt.history, nil)
+				for ndc := range tt.historyClasses {
+					repo.On("GetDrugClasses", []string{ndc}).Return(tt.historyClasses, nil)
+				}
+			}
+			if tt.status == models.ClaimStatusPaid {
+				repo.On("GetBenefitPlan", testPlanID).Return(nil, models.ErrPlanNotFound)
+			}
+			repo.On("CreateClaim", mock.Anything, mock.Anything).Return(nil)
+
+			response, err := claimsService.SubmitClaim(claimRequest())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, response.Status)
+			assert.Equal(t, tt.priorAuthID, response.PriorAuthID)
+			if tt.status == models.ClaimStatusRejected {
+				require.Len(t, response.Rejects, 1)
+				assert.Equal(t, "608", response.Rejects[0].Code)
+			} else {
+				assert.Empty(t, response.Rejects)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSubmitClaim_Edits(t *testing.T) {
+	quantityLimit := 10.0
+	priorAuthID := 7
+	serviceDate := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	formulary := func(update func(entry *models.FormularyEntry)) *models.FormularyEntry {
+		entry := &models.FormularyEntry{PlanID: testPlanID, NDC: testNDC, Tier: 2}
+		update(entry)
+		return entry
+	}
+
+	tests := []struct {
+		name        string
+		request     func(request *models.ClaimRequest)
+		setup       func(repo *MockClaimsRepository)
+		rejects     []string
+		priorAuthID *int
+		costShare   *models.CostShare
+	}{
+		{
+			name:      "paid",
+			costShare: &models.CostShare{Copay: 10, PatientPay: 10, PlanPaid: 15},
+		},
+		{
+			name: "pharmacy inactive",
+			setup: func(repo *MockClaimsRepository) {
+				repo.On("GetPharmacyByNPI", testNPI).Return(&models.Pharmacy{NPI: testNPI, Chain: "health"}, nil)
+			},
+			rejects: []string{"40"},
+		},
+		{
+			name: "member not found",
+			setup: func(repo *MockClaimsRepository) {
+				repo.On("GetMember", testMemberID).Return(nil, models.ErrMemberNotFound)
+			},
+			rejects: []string{"52"},
+		},
+		{
+			name: "before coverage",
+			setup: func(repo *MockClaimsRepository) {
+				repo.On("GetMember", testMemberID).Return(&models.Member{MemberID: testMemberID, PlanID: testPlanID, EffectiveDate: serviceDate.AddDate(0, 0, 1)}, nil)
+			},
+			rejects: []string{"67"},
+		},
+		{
+			name: "coverage terminated",
+			setup: func(repo *MockClaimsRepository) {
+				terminated := serviceDate.AddDate(0, 0, -1)
+				repo.On("GetMember", testMemberID).Return(&models.Member{MemberID: testMemberID, PlanID: testPlanID, EffectiveDate: serviceDate.AddDate(-1, 0, 0), TerminationDate: &terminated}, nil)
+			},
+			rejects: []string{"69"},
+		},
+		{
+			name:    "invalid ndc",
+			request: func(request *models.ClaimRequest) { request.NDC = "12345" },
+			rejects: []string{"21"},
+		},
+		{
+			name: "not on formulary",
+			setup: func(repo *MockClaimsRepository) {
+				repo.On("GetFormularyEntry", testPlanID, testNDC).Return(nil, models.ErrNotOnFormulary)
+			},
+			rejects: []string{"70"},
+		},
+		{
+			name: "formulary quantity",
+			setup: func(repo *MockClaimsRepository) {
+				repo.On("GetFormularyEntry", testPlanID, testNDC).Return(formulary(func(entry *models.FormularyEntry) { entry.QuantityLimit = &quantityLimit }), nil)
+			},
+			rejects: []string{"76"},
+		},
+		{
+			name: "prior auth missing",
+			setup: func(repo *MockClaimsRepository) {
+				repo.On("GetFormularyEntry", testPlanID, testNDC).Return(formulary(func(entry *models.FormularyEntry) { entry.PriorAuthRequired = true }), nil)
+				repo.On("FindPriorAuth", mock.Anything, "statin").Return(nil, models.ErrPriorAuthNotFound)
+			},
+			rejects: []string{"75"},
+		},
+		{
+			name: "prior auth found",
+			setup: func(repo *MockClaimsRepository) {
+				repo.On("GetFormularyEntry", testPlanID, testNDC).Return(formulary(func(entry *models.FormularyEntry) { entry.PriorAuthRequired = true }), nil)
+				repo.On("FindPriorAuth", mock.Anything, "statin").Return(&models.PriorAuth{ID: priorAuthID}, nil)
+			},
+			priorAuthID: &priorAuthID,
+			costShare:   &models.CostShare{Copay: 10, PatientPay: 10, PlanPaid: 15},
+		},
+		{
+			name:    "prior auth found with a later reject",
+			request: func(request *models.ClaimRequest) { request.Price = 0 },
+			setup: func(repo *MockClaimsRepository) {
+				repo.On("GetFormularyEntry", testPlanID, testNDC).Return(formulary(func(entry *models.FormularyEntry) { entry.PriorAuthRequired = true }), nil)
+				repo.On("FindPriorAuth", mock.Anything, "statin").Return(&models.PriorAuth{ID: priorAuthID}, nil)
+			},
+			rejects: []string{"DU"},
+		},
+		{
+			name: "step therapy",
+			setup: func(repo *MockClaimsRepository) {
+				repo.On("GetFormularyEntry", testPlanID, testNDC).Return(formulary(func(entry *models.FormularyEntry) { entry.StepTherapyRequired = true }), nil)
+				repo.On("FindPriorAuth", mock.Anything, "statin").Return(nil, models.ErrPriorAuthNotFound)
+			},
+			rejects: []string{"608"},
+		},
+		{
+			name: "duplicate",
+			setup: func(repo *MockClaimsRepository) {
+				repo.On("FindDuplicateClaim", mock.Anything, mock.Anything).Return(&models.Claim{ID: uuid.New()}, nil)
+			},
+			rejects: []string{"83"},
+		},
+		{
+			name: "refill too soon",
+			setup: func(repo *MockClaimsRepository) {
+				repo.On("FindMemberClaimHistory", mock.Anything, mock.Anything).Return([]models.Claim{{
+					ID:          uuid.New(),
+					NDC:         testNDC,
+					DaysSupply:  30,
+					MemberID:    testMemberID,
+					ServiceDate: serviceDate.AddDate(0, 0, -5),
+					Status:      models.ClaimStatusPaid,
+				}}, nil)
+			},
+			rejects: []string{"79"},
+		},
+		{
+			name:    "quantity limit",
+			request: func(request *models.ClaimRequest) { request.Quantity = 20000 },
+			rejects: []string{"76"},
+		},
+		{
+			name:    "zero price",
+			request: func(request *models.ClaimRequest) { request.Price = 0 },
+			rejects: []string{"DU"},
+		},
+		{
+			name:    "price over maximum",
+			request: func(request *models.ClaimRequest) { request.Price = 30000 },
+			rejects: []string{"78"},
+		},
+		{
+			name: "no benefit plan",
+			setup: func(repo *MockClaimsRepository) {
+				repo.On("GetBenefitPlan", testPlanID).Return(nil, models.ErrPlanNotFound)
+			},
+			costShare: &models.CostShare{PlanPaid: 25},
+		},
+		{
+			name:    "every reject is reported",
+			request: func(request *models.ClaimRequest) { request.Price = 0 },
+			setup: func(repo *MockClaimsRepository) {
+				repo.On("GetPharmacyByNPI", testNPI).Return(&models.Pharmacy{NPI: testNPI, Chain: "health"}, nil)
+				repo.On("FindDuplicateClaim", mock.Anything, mock.Anything).Return(&models.Claim{ID: uuid.New()}, nil)
+			},
+			rejects: []string{"40", "83", "DU"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockClaimsRepository{}
+			claimsService := newClaimsService(t, repo, service.DefaultClaimsPolicy())
+
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			repo.On("GetPharmacyByNPI", testNPI).Return(&models.Pharmacy{NPI: testNPI, Chain: "health", Active: true}, nil).Maybe()
+			repo.On("GetMember", testMemberID).Return(&models.Member{MemberID: testMemberID, PlanID: testPlanID, EffectiveDate: serviceDate.AddDate(-1, 0, 0)}, nil).Maybe()
+			repo.On("GetFormularyEntry", testPlanID, mock.Anything).Return(formulary(func(*models.FormularyEntry) {}), nil).Maybe()
+			repo.On("GetDrugClasses", mock.Anything).Return(map[string]string{testNDC: "statin"}, nil).Maybe()
+			repo.On("FindMemberClaimHistory", mock.Anything, mock.Anything).Return([]models.Claim{}, nil).Maybe()
+			repo.On("ListDrugInteractions", "statin").Return([]models.DrugInteraction{}, nil).Maybe()
+			repo.On("FindDuplicateClaim", mock.Anything, mock.Anything).Return(nil, models.ErrClaimNotFound).Maybe()
+			repo.On("GetContractForChain", "health", mock.Anything).Return(nil, models.ErrContractNotFound).Maybe()
+			repo.On("GetDrugPrice", mock.Anything, mock.Anything).Return(nil, models.ErrDrugPriceNotFound).Maybe()
+			repo.On("GetBenefitPlan", testPlanID).Return(&models.BenefitPlan{
+				PlanID: testPlanID,
+				Tiers:  []models.BenefitPlanTier{{Tier: 2, Copay: 10}},
+			}, nil).Maybe()
+
+			var created *models.Claim
+			var costShare repository.CostShareFunc
+			repo.On("CreateClaim", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				created = args.Get(0).(*models.Claim)
+				costShare = args.Get(1).(repository.CostShareFunc)
+				if costShare != nil {
+					created.CostShare = costShare(models.Accumulator{MemberID: testMemberID})
+				}
+			}).Return(nil)
+
+			request := claimRequest()
+			if tt.request != nil {
+				tt.request(&request)
+			}
+			response, err := claimsService.SubmitClaim(request)
+
+			require.NoError(t, err)
+			require.NotNil(t, created)
+			assert.Equal(t, tt.priorAuthID, response.PriorAuthID)
+			assert.Equal(t, tt.priorAuthID, created.PriorAuthID)
+
+			if len(tt.rejects) > 0 {
+				codes := make([]string, len(response.Rejects))
+				for i, reject := range response.Rejects {
+					codes[i] = reject.Code
+				}
+				assert.Equal(t, tt.rejects, codes)
+				assert.Equal(t, models.ClaimStatusRejected, response.Status)
+				assert.Equal(t, models.ClaimStatusRejected, created.Status)
+				assert.Nil(t, response.Pricing)
+				assert.Nil(t, response.CostShare)
+				assert.Nil(t, costShare)
+				assert.Equal(t, models.ClaimPricing{}, created.Pricing)
+				assert.Equal(t, models.CostShare{}, created.CostShare)
+				return
+			}
+
+			assert.Empty(t, response.Rejects)
+			assert.Equal(t, models.ClaimStatusPaid, response.Status)
+			assert.Equal(t, models.ClaimStatusPaid, created.Status)
+			require.NotNil(t, response.Pricing)
+			assert.Equal(t, 25.0, response.Pricing.Total)
+			assert.Equal(t, tt.costShare, response.CostShare)
+		})
+	}
+}
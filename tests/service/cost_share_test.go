@@ -0,0 +1,87 @@
+package service
+
+import (
+	"testing"
+
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateCostShare(t *testing.T) {
+	oopMax := 3000.0
+	plan := &models.BenefitPlan{
+		PlanID:     "COMMERCIAL",
+		Deductible: 250,
+		OOPMax:     &oopMax,
+		Tiers: []models.BenefitPlanTier{
+			{Tier: 1, Copay: 10},
+			{Tier: 3, CoinsurancePercent: 25},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		tier        int
+		accumulator models.Accumulator
+		allowed     float64
+		expected    models.CostShare
+	}{
+		{
+			name:        "Claim below remaining deductible",
+			tier:        1,
+			accumulator: models.Accumulator{},
+			allowed:     100,
+			expected:    models.CostShare{Deductible: 100, PatientPay: 100, PlanPaid: 0},
+		},
+		{
+			name:        "Deductible met then copay",
+			tier:        1,
+			accumulator: models.Accumulator{DeductibleMet: 200, OOPMet: 200},
+			allowed:     100,
+			expected:    models.CostShare{Deductible: 50, Copay: 10, PatientPay: 60, PlanPaid: 40},
+		},
+		{
+			name:        "Copay capped at allowed amount",
+			tier:        1,
+			accumulator: models.Accumulator{DeductibleMet: 250, OOPMet: 250},
+			allowed:     6.50,
+			expected:    models.CostShare{Copay: 6.50, PatientPay: 6.50, PlanPaid: 0},
+		},
+		{
+			name:        "Coinsurance after deductible",
+			tier:        3,
+			accumulator: models.Accumulator{DeductibleMet: 250, OOPMet: 400},
+			allowed:     106.05,
+			expected:    models.CostShare{Coinsurance: 26.51, PatientPay: 26.51, PlanPaid: 79.54},
+		},
+		{
+			name:        "Out-of-pocket maximum limits patient pay",
+			tier:        3,
+			accumulator: models.Accumulator{DeductibleMet: 250, OOPMet: 2990},
+			allowed:     200,
+			expected:    models.CostShare{Coinsurance: 10, PatientPay: 10, PlanPaid: 190},
+		},
+		{
+			name:        "Out-of-pocket maximum reached",
+			tier:        1,
+			accumulator: models.Accumulator{DeductibleMet: 250, OOPMet: 3000},
+			allowed:     100,
+			expected:    models.CostShare{PlanPaid: 100},
+		},
+		{
+			name:        "Tier without cost share applies deductible only",
+			tier:        2,
+			accumulator: models.Accumulator{DeductibleMet: 240, OOPMet: 240},
+			allowed:     100,
+			expected:    models.CostShare{Deductible: 10, PatientPay: 10, PlanPaid: 90},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, service.CalculateCostShare(plan, tt.tier, tt.accumulator, tt.allowed))
+		})
+	}
+}
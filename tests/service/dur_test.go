@@ -0,0 +1,143 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/ncpdp"
+	"pharmacyclaims/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReviewDrugUtilization(t *testing.T) {
+	serviceDate := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	claim := &models.Claim{
+		NDC:         "00015066812",
+		NPI:         "1234567890",
+		ServiceDate: serviceDate,
+		DaysSupply:  30,
+	}
+
+	classes := map[string]string{
+		"00015066812": "ssri",
+		"00078017705": "maoi",
+		"00046110481": "nsaid",
+		"00054027225": "opioid",
+		"00002323401": "statin",
+	}
+	interactions := []models.DrugInteraction{
+		{ClassA: "maoi", ClassB: "ssri", Severity: models.DURSeverityMajor, Description: "risk of serotonin syndrome"},
+		{ClassA: "nsaid", ClassB: "ssri", Severity: models.DURSeverityModerate, Description: "increased risk of gastrointestinal bleeding"},
+	}
+
+	previous := func(ndc, npi string, daysAgo, daysSupply int) models.Claim {
+		return models.Claim{
+			ID:          uuid.New(),
+			NDC:         ndc,
+			NPI:         npi,
+			Quantity:    30,
+			DaysSupply:  daysSupply,
+			ServiceDate: serviceDate.AddDate(0, 0, -daysAgo),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		history  []models.Claim
+		expected []models.DURAlert
+	}{
+		{
+			name:     "No history",
+			history:  nil,
+			expected: nil,
+		},
+		{
+			name:    "Refill too soon",
+			history: []models.Claim{previous("00015066812", "1234567890", 10, 30)},
+			expected: []models.DURAlert{
+				{ReasonCode: ncpdp.DURReasonEarlyRefill, Hard: true},
+			},
+		},
+		{
+			name:     "Refill after threshold",
+			history:  []models.Claim{previous("00015066812", "1234567890", 23, 30)},
+			expected: nil,
+		},
+		{
+			name:    "Missing days supply uses default",
+			history: []models.Claim{previous("00015066812", "1234567890", 20, 0)},
+			expected: []models.DURAlert{
+				{ReasonCode: ncpdp.DURReasonEarlyRefill, Hard: true},
+			},
+		},
+		{
+			name:    "Major interaction is hard",
+			history: []models.Claim{previous("00078017705", "9876543210", 5, 30)},
+			expected: []models.DURAlert{
+				{ReasonCode: ncpdp.DURReasonDrugInteraction, ClinicalSignificance: ncpdp.ClinicalSignificanceMajor, Hard: true, OtherPharmacy: true},
+			},
+		},
+		{
+			name:    "Moderate interaction is soft",
+			history: []models.Claim{previous("00046110481", "1234567890", 5, 30)},
+			expected: []models.DURAlert{
+				{ReasonCode: ncpdp.DURReasonDrugInteraction, ClinicalSignificance: ncpdp.ClinicalSignificanceModerate},
+			},
+		},
+		{
+			name:     "Interacting fill no longer active",
+			history:  []models.Claim{previous("00078017705", "1234567890", 45, 30)},
+			expected: nil,
+		},
+		{
+			name: "Therapeutic duplication",
+			history: []models.Claim{
+				previous("00015066813", "1234567890", 5, 30),
+			},
+			expected: []models.DURAlert{
+				{ReasonCode: ncpdp.DURReasonTherapeuticDuplication, ClinicalSignificance: ncpdp.ClinicalSignificanceModerate},
+			},
+		},
+		{
+			name:     "Unrelated drug",
+			history:  []models.Claim{previous("00002323401", "1234567890", 5, 30)},
+			expected: nil,
+		},
+		{
+			name: "Repeated fills alert once",
+			history: []models.Claim{
+				previous("00046110481", "1234567890", 5, 30),
+				previous("00046110481", "1234567890", 15, 30),
+			},
+			expected: []models.DURAlert{
+				{ReasonCode: ncpdp.DURReasonDrugInteraction, ClinicalSignificance: ncpdp.ClinicalSignificanceModerate},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := map[string]string{"00015066813": "ssri"}
+			for ndc, drugClass := range classes {
+				lookup[ndc] = drugClass
+			}
+
+			alerts := service.ReviewDrugUtilization(claim, tt.history, lookup, interactions, 75)
+
+			assert.Len(t, alerts, len(tt.expected))
+			for i, expected := range tt.expected {
+				if i >= len(alerts) {
+					break
+				}
+				assert.Equal(t, expected.ReasonCode, alerts[i].ReasonCode)
+				assert.Equal(t, expected.ClinicalSignificance, alerts[i].ClinicalSignificance)
+				assert.Equal(t, expected.Hard, alerts[i].Hard)
+				assert.Equal(t, expected.OtherPharmacy, alerts[i].OtherPharmacy)
+				assert.Equal(t, tt.history[0].ID, alerts[i].OtherClaimID)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"testing"
+
+	"pharmacyclaims/internal/models"
+	"pharmacyclaims/internal/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func unitCost(value float64) *float64 {
+	return &value
+}
+
+func TestPriceClaim(t *testing.T) {
+	formula := service.PricingFormula{
+		Basis:         models.PriceBasisAWP,
+		Percent:       -15,
+		DispensingFee: 2.00,
+	}
+
+	tests := []struct {
+		name      string
+		formula   service.PricingFormula
+		price     *models.DrugPrice
+		quantity  float64
+		submitted float64
+		expected  models.ClaimPricing
+	}{
+		{
+			name:      "No price on file pays submitted",
+			formula:   formula,
+			price:     nil,
+			quantity:  30,
+			submitted: 25.99,
+			expected:  models.ClaimPricing{Basis: models.PriceBasisSubmitted, IngredientCost: 25.99, Total: 25.99},
+		},
+		{
+			name:      "Formula lower than submitted",
+			formula:   formula,
+			price:     &models.DrugPrice{AWP: unitCost(4.00)},
+			quantity:  30,
+			submitted: 500,
+			expected:  models.ClaimPricing{Basis: models.PriceBasisAWP, IngredientCost: 102.00, DispensingFee: 2.00, Total: 104.00},
+		},
+		{
+			name:      "Submitted lower than formula",
+			formula:   formula,
+			price:     &models.DrugPrice{AWP: unitCost(4.00)},
+			quantity:  30,
+			submitted: 50,
+			expected:  models.ClaimPricing{Basis: models.PriceBasisSubmitted, IngredientCost: 50, Total: 50},
+		},
+		{
+			name:      "MAC caps ingredient cost",
+			formula:   formula,
+			price:     &models.DrugPrice{AWP: unitCost(4.00), MAC: unitCost(1.10)},
+			quantity:  30,
+			submitted: 500,
+			expected:  models.ClaimPricing{Basis: models.PriceBasisMAC, IngredientCost: 33.00, DispensingFee: 2.00, Total: 35.00},
+		},
+		{
+			name:      "MAC used when basis missing",
+			formula:   formula,
+			price:     &models.DrugPrice{NADAC: unitCost(0.90), MAC: unitCost(1.10)},
+			quantity:  10,
+			submitted: 500,
+			expected:  models.ClaimPricing{Basis: models.PriceBasisMAC, IngredientCost: 11.00, DispensingFee: 2.00, Total: 13.00},
+		},
+		{
+			name:      "Basis missing without MAC pays submitted",
+			formula:   formula,
+			price:     &models.DrugPrice{NADAC: unitCost(0.90)},
+			quantity:  10,
+			submitted: 500,
+			expected:  models.ClaimPricing{Basis: models.PriceBasisSubmitted, IngredientCost: 500, Total: 500},
+		},
+		{
+			name:      "NADAC plus markup",
+			formula:   service.PricingFormula{Basis: models.PriceBasisNADAC, Percent: 10, DispensingFee: 10},
+			price:     &models.DrugPrice{NADAC: unitCost(0.50)},
+			quantity:  90,
+			submitted: 500,
+			expected:  models.ClaimPricing{Basis: models.PriceBasisNADAC, IngredientCost: 49.50, DispensingFee: 10, Total: 59.50},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pricing := service.PriceClaim(tt.formula, tt.price, tt.quantity, tt.submitted)
+			assert.Equal(t, tt.expected.Basis, pricing.Basis)
+			assert.InDelta(t, tt.expected.IngredientCost, pricing.IngredientCost, 0.001)
+			assert.InDelta(t, tt.expected.DispensingFee, pricing.DispensingFee, 0.001)
+			assert.InDelta(t, tt.expected.Total, pricing.Total, 0.001)
+		})
+	}
+}